@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wesleysantana/config-loader/internal/astconfig"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+	urlPtrType   = reflect.TypeOf(&url.URL{})
+)
+
+// generate lê a struct typeName do pacote em dir e retorna o código-fonte formatado
+// da função de carregamento. O arquivo output (se existir) é ignorado na leitura,
+// para que uma versão antiga do código gerado não interfira. Com validate, a função
+// também verifica as tags `enum`, `min` e `max`, como Load com WithValidation.
+func generate(dir, typeName, funcName, output string, validate bool) ([]byte, error) {
+	p, err := astconfig.Parse(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := p.Struct(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{validate: validate}
+	g.printf("// Code generated by configloader-gen; DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", p.Name)
+	g.printf("import configloader %q\n\n", "github.com/wesleysantana/config-loader")
+	g.printf("// %s carrega %s a partir de src sem reflection, com as mesmas regras de\n", funcName, typeName)
+	if validate {
+		g.printf("// configloader.LoadFromSource com WithValidation (defaults, required, tipos e validações).\n")
+	} else {
+		g.printf("// configloader.LoadFromSource (defaults, required e tipos).\n")
+	}
+	g.printf("func %s(src configloader.Source) (%s, error) {\n", funcName, typeName)
+	g.printf("var cfg %s\n", typeName)
+	g.printf("r := configloader.NewResolver(src)\n\n")
+	if err := g.fields(fields, "cfg", nil); err != nil {
+		return nil, err
+	}
+	g.printf("return cfg, r.Err()\n}\n")
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// generator acumula o código gerado.
+type generator struct {
+	buf bytes.Buffer
+
+	// validate indica se as tags `enum`, `min` e `max` geram chamadas a r.Check.
+	validate bool
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// fields gera o código de cada campo, na ordem de declaração. selector é a expressão
+// Go da struct atual (ex: "cfg.Database") e groups são os nomes usados no caminho
+// das mensagens de erro (structs embutidas não entram no caminho, como em Load).
+func (g *generator) fields(fields []*astconfig.Field, selector string, groups []string) error {
+	for _, f := range fields {
+		target := selector + "." + f.Name
+
+		if !f.HasEnv() {
+			if f.Embedded {
+				if err := g.fields(f.Fields, target, groups); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := g.fields(f.Fields, target, append(append([]string{}, groups...), f.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := g.field(f, target, strings.Join(append(append([]string{}, groups...), f.Name), ".")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field gera a leitura, conversão e validação de um campo com tag `env`.
+func (g *generator) field(f *astconfig.Field, target, path string) error {
+	parts := strings.SplitN(f.Tag.Get("env"), ",", 2)
+	name, fallback := parts[0], ""
+	if len(parts) > 1 {
+		fallback = parts[1]
+	}
+
+	// Campos não exportados não são preenchidos por Load, mas required continua valendo.
+	if !f.Exported {
+		g.printf("r.Lookup(%q, %q)\n\n", name, fallback)
+		return nil
+	}
+
+	parse, value, err := conversion(f)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", path, err)
+	}
+	if f.Secret {
+		value = "configloader.NewSecret(" + value + ")"
+	}
+
+	g.printf("if raw, ok := r.Lookup(%q, %q); ok {\n", name, fallback)
+	if parse != "" {
+		g.printf("v, err := %s(raw)\n", parse)
+		g.printf("if err != nil {\nreturn cfg, r.FieldError(%q, err)\n}\n", path)
+	}
+	g.printf("%s = %s\n", target, value)
+	if g.validate && hasConstraints(f.Tag) {
+		g.printf("r.Check(%q, %q, %s, %s)\n", name, f.Name, target, tagLiteral(f.Tag))
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+// conversion retorna a função de conversão do configloader para o tipo do campo
+// (vazia quando não há conversão que possa falhar) e a expressão do valor convertido.
+func conversion(f *astconfig.Field) (parse, value string, err error) {
+	convert := func(expr, natural string) string {
+		if f.GoType == natural {
+			return expr
+		}
+		return f.GoType + "(" + expr + ")"
+	}
+
+	switch t := f.Type; {
+	case t == durationType:
+		return "configloader.ParseDuration", "v", nil
+	case t == urlType:
+		return "configloader.ParseURL", "*v", nil
+	case t == urlPtrType:
+		return "configloader.ParseURL", "v", nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		return "", convert("configloader.ParseStringSlice(raw)", "[]string"), nil
+	case t.Kind() == reflect.String:
+		return "", convert("raw", "string"), nil
+	case t.Kind() == reflect.Bool:
+		return "configloader.ParseBool", convert("v", "bool"), nil
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		return "configloader.ParseInt", convert("v", "int64"), nil
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "configloader.ParseFloat", convert("v", "float64"), nil
+	default:
+		return "", "", fmt.Errorf("unsupported field type %s", t)
+	}
+}
+
+// hasConstraints indica se a tag declara validações `enum`, `min` ou `max`.
+func hasConstraints(tag reflect.StructTag) bool {
+	for _, key := range []string{"enum", "min", "max"} {
+		if _, ok := tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tagLiteral formata a tag como literal Go, preferindo a forma raw (entre crases).
+func tagLiteral(tag reflect.StructTag) string {
+	if !strings.Contains(string(tag), "`") {
+		return "`" + string(tag) + "`"
+	}
+	return strconv.Quote(string(tag))
+}
@@ -0,0 +1,78 @@
+// Command configloader-gen gera, para uma struct com tags `env`, uma função de
+// carregamento tipada que não usa reflection:
+//
+//	func LoadConfig(src configloader.Source) (Config, error)
+//
+// A função gerada segue as mesmas regras de configloader.LoadFromSource: defaults,
+// required, conversão de tipos e mensagens de erro; com -validate, também as
+// validações `enum`/`min`/`max` de WithValidation. Use
+// configloadertest.AssertEquivalent para verificar que as duas concordam.
+//
+// Uso com go generate, no arquivo que declara a struct:
+//
+//	//go:generate go run github.com/wesleysantana/config-loader/cmd/configloader-gen -type Config
+//
+// Flags:
+//
+//	-type    nome da struct (obrigatório)
+//	-dir     diretório do pacote (padrão: diretório atual)
+//	-output  arquivo gerado (padrão: <type>_loader_gen.go, em minúsculas)
+//	-func    nome da função gerada (padrão: Load<type>)
+//	-validate  verifica as tags enum, min e max (como configloader.WithValidation)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run executa o gerador e retorna o código de saída.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("configloader-gen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	typeName := fs.String("type", "", "config struct name")
+	dir := fs.String("dir", ".", "package directory")
+	output := fs.String("output", "", "output file (default: <type>_loader_gen.go)")
+	funcName := fs.String("func", "", "generated function name (default: Load<type>)")
+	validate := fs.Bool("validate", false, "check enum, min and max tags (as configloader.WithValidation)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *typeName == "" {
+		fmt.Fprintln(stderr, "configloader-gen: -type is required")
+		fs.Usage()
+		return 2
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_loader_gen.go"
+	}
+	if *funcName == "" {
+		*funcName = "Load" + *typeName
+	}
+	if !filepath.IsAbs(*output) {
+		*output = filepath.Join(*dir, *output)
+	}
+
+	src, err := generate(*dir, *typeName, *funcName, filepath.Base(*output), *validate)
+	if err != nil {
+		fmt.Fprintf(stderr, "configloader-gen: %v\n", err)
+		return 1
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintf(stderr, "configloader-gen: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedCodeIsUpToDate testa que internal/gentest/config_loader_gen.go
+// corresponde à saída atual do gerador
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	want, err := os.ReadFile(filepath.Join(dir, "config_loader_gen.go"))
+	if err != nil {
+		t.Fatalf("Expected committed generated file, got %v", err)
+	}
+
+	got, err := generate(dir, "Config", "LoadConfig", "config_loader_gen.go", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected generated code to match internal/gentest/config_loader_gen.go; run go generate ./internal/gentest")
+	}
+}
+
+// TestRunWritesOutput testa a geração com nome de função e arquivo personalizados
+func TestRunWritesOutput(t *testing.T) {
+	dir := t.TempDir()
+	src := "package app\n\ntype Settings struct {\n\tPort int `env:\"PORT,8080\" max:\"9999\"`\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "settings.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	code := run([]string{"-dir", dir, "-type", "Settings", "-func", "ReadSettings", "-output", "gen.go"}, &stderr)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, "gen.go"))
+	if err != nil {
+		t.Fatalf("Expected output file, got %v", err)
+	}
+	for _, want := range []string{
+		"package app",
+		"func ReadSettings(src configloader.Source) (Settings, error)",
+		`r.Lookup("PORT", "8080")`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	// Sem -validate, as tags de validação não geram r.Check
+	if strings.Contains(string(out), "r.Check(") {
+		t.Errorf("Expected no r.Check without -validate, got:\n%s", out)
+	}
+}
+
+// TestRunErrors testa os códigos de saída em erros de uso e de geração
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := "package app\n\ntype Config struct {\n\tHosts map[string]string `env:\"HOSTS\"`\n}\n\ntype Name string\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args    []string
+		code    int
+		message string
+	}{
+		{[]string{"-dir", dir}, 2, "-type is required"},
+		{[]string{"-dir", dir, "-type", "Missing"}, 1, "type Missing not found"},
+		{[]string{"-dir", dir, "-type", "Name"}, 1, "type Name is not a struct"},
+		{[]string{"-dir", dir, "-type", "Config"}, 1, "unsupported type map[string]string"},
+	}
+
+	for _, tt := range tests {
+		var stderr bytes.Buffer
+		if code := run(tt.args, &stderr); code != tt.code {
+			t.Errorf("Expected exit code %d for %v, got %d", tt.code, tt.args, code)
+		}
+		if !strings.Contains(stderr.String(), tt.message) {
+			t.Errorf("Expected stderr to contain %q, got %q", tt.message, stderr.String())
+		}
+	}
+}
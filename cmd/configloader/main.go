@@ -0,0 +1,255 @@
+// Command configloader valida, imprime e documenta configurações baseadas em
+// structs com tags `env` sem precisar iniciar a aplicação.
+//
+// A struct é descrita por um JSON Schema exportado com configloader.Schema
+// (-schema) ou lida diretamente do código-fonte (-pkg e -type).
+//
+// Uso:
+//
+//	configloader validate -schema config.schema.json -env-file .env
+//	configloader print -pkg ./internal/config -type Config -format json
+//	configloader example -pkg ./internal/config -type Config -format markdown
+//	configloader lint -pkg ./internal/config -type Config -env-file .env
+//
+// Comandos:
+//
+//	validate  verifica o ambiente e os arquivos .env (campos obrigatórios, tipos, enum/min/max)
+//	print     imprime a configuração efetiva com valores sensíveis mascarados
+//	example   gera um .env.example (env), a referência Markdown (markdown), o JSON Schema (schema)
+//	          ou o texto de ajuda (usage)
+//	lint      verifica a sintaxe dos arquivos .env, chaves duplicadas ou não usadas e valores inválidos
+//
+// Os comandos validate, print e lint verificam as tags enum/min/max como
+// configloader.Load com WithValidation.
+//
+// O código de saída é 1 quando a configuração é inválida e 2 em erros de uso.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	configloader "github.com/wesleysantana/config-loader"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// stringList é um flag.Value que acumula valores de um flag repetido.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// errUsage indica erro de uso (flags inválidas ou ausentes).
+var errUsage = errors.New("usage error")
+
+// run executa o comando e retorna o código de saída.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	command := args[0]
+	fs := flag.NewFlagSet("configloader "+command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	schemaFile := fs.String("schema", "", "JSON Schema exported with configloader.Schema")
+	pkg := fs.String("pkg", "", "Go package (directory or import path) declaring the config struct")
+	typeName := fs.String("type", "", "config struct name (with -pkg)")
+	format := fs.String("format", "", "output format (print: text|json|yaml|dotenv|markdown; example: env|markdown|schema|usage)")
+	var envFiles stringList
+	fs.Var(&envFiles, "env-file", "`.env` file to load (repeatable); default: .env in the current directory if present")
+
+	commands := map[string]func(configType reflect.Type) int{
+		"validate": func(t reflect.Type) int { return validate(t, envFiles, stdout, stderr) },
+		"print":    func(t reflect.Type) int { return printConfig(t, envFiles, *format, stdout, stderr) },
+		"example":  func(t reflect.Type) int { return example(t, *format, stdout, stderr) },
+		"lint":     func(t reflect.Type) int { return lint(t, envFiles, stdout, stderr) },
+	}
+	handler, ok := commands[command]
+	if !ok {
+		fmt.Fprintf(stderr, "configloader: unknown command %q\n", command)
+		printUsage(stderr)
+		return 2
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	configType, err := loadConfigType(*schemaFile, *pkg, *typeName)
+	if err != nil {
+		fmt.Fprintf(stderr, "configloader: %v\n", err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+	return handler(configType)
+}
+
+// loadConfigType obtém o tipo da struct de configuração a partir do schema ou do código-fonte.
+func loadConfigType(schemaFile, pkg, typeName string) (reflect.Type, error) {
+	switch {
+	case schemaFile != "" && pkg != "":
+		return nil, fmt.Errorf("%w: -schema and -pkg are mutually exclusive", errUsage)
+	case schemaFile != "":
+		data, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return nil, err
+		}
+		var doc configloader.SchemaDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %w", schemaFile, err)
+		}
+		return structFromSchema(doc)
+	case pkg != "":
+		if typeName == "" {
+			return nil, fmt.Errorf("%w: -type is required with -pkg", errUsage)
+		}
+		return structFromSource(pkg, typeName)
+	default:
+		return nil, fmt.Errorf("%w: one of -schema or -pkg is required", errUsage)
+	}
+}
+
+// loadConfig carrega uma nova instância do tipo com as regras de configloader.Load,
+// incluindo as validações enum/min/max.
+func loadConfig(configType reflect.Type, envFiles []string) (reflect.Value, error) {
+	cfg := reflect.New(configType)
+	err := configloader.Load(cfg.Interface(), configloader.LoadOptions{
+		EnvFiles:  envFiles,
+		UseSystem: true,
+		Validate:  true,
+	})
+	return cfg, err
+}
+
+// validate verifica a configuração e reporta os erros encontrados.
+func validate(configType reflect.Type, envFiles []string, stdout, stderr io.Writer) int {
+	if _, err := loadConfig(configType, envFiles); err != nil {
+		fmt.Fprintf(stderr, "configuration is invalid: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintln(stdout, "configuration is valid")
+	return 0
+}
+
+// printConfig imprime a configuração efetiva. Mesmo se inválida, os valores
+// carregados são impressos antes do erro.
+func printConfig(configType reflect.Type, envFiles []string, format string, stdout, stderr io.Writer) int {
+	if format == "" {
+		format = string(configloader.FormatText)
+	}
+
+	cfg, loadErr := loadConfig(configType, envFiles)
+	out, err := configloader.Dump(cfg.Interface(), configloader.Format(format))
+	if err != nil {
+		fmt.Fprintf(stderr, "configloader: %v\n", err)
+		return 2
+	}
+	fmt.Fprint(stdout, out)
+
+	if loadErr != nil {
+		fmt.Fprintf(stderr, "configuration is invalid: %v\n", loadErr)
+		return 1
+	}
+	return 0
+}
+
+// example gera arquivos de exemplo e documentação a partir da struct.
+func example(configType reflect.Type, format string, stdout, stderr io.Writer) int {
+	cfg := reflect.New(configType).Interface()
+
+	var out string
+	var err error
+	switch format {
+	case "", "env":
+		out, err = configloader.GenerateEnvExample(cfg)
+	case "markdown":
+		out, err = configloader.GenerateReference(cfg)
+	case "usage":
+		out, err = configloader.Usage(cfg)
+	case "schema":
+		var data []byte
+		data, err = configloader.Schema(cfg)
+		out = string(data)
+	default:
+		fmt.Fprintf(stderr, "configloader: unsupported example format %q\n", format)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "configloader: %v\n", err)
+		return 1
+	}
+	fmt.Fprint(stdout, out)
+	return 0
+}
+
+// lint analisa os arquivos .env (padrão: .env) com configloader.LintEnvFile e
+// imprime cada problema como arquivo:linha: mensagem.
+func lint(configType reflect.Type, envFiles []string, stdout, stderr io.Writer) int {
+	if len(envFiles) == 0 {
+		envFiles = []string{".env"}
+	}
+
+	cfg := reflect.New(configType).Interface()
+	found := 0
+	for _, file := range envFiles {
+		issues, err := configloader.LintEnvFile(file, cfg, configloader.WithValidation())
+		if err != nil {
+			fmt.Fprintf(stderr, "configloader: %v\n", err)
+			return 1
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(stdout, issue)
+		}
+		found += len(issues)
+	}
+
+	if found > 0 {
+		fmt.Fprintf(stderr, "%d issue(s) found\n", found)
+		return 1
+	}
+	fmt.Fprintln(stdout, "no issues found")
+	return 0
+}
+
+// printUsage escreve a ajuda do comando.
+func printUsage(w io.Writer) {
+	fmt.Fprint(w, `usage: configloader <command> [flags]
+
+commands:
+  validate  check the environment and .env files for missing or invalid values
+  print     print the effective configuration with sensitive values masked
+  example   emit a .env.example, Markdown reference, JSON Schema or --help text
+  lint      report malformed lines, duplicate or unused keys and invalid values in .env files
+
+flags:
+  -schema file      JSON Schema exported with configloader.Schema
+  -pkg path         Go package (directory or import path) declaring the config struct
+  -type name        config struct name (with -pkg)
+  -env-file file    .env file to load (repeatable)
+  -format name      print: text|json|yaml|dotenv|markdown; example: env|markdown|schema|usage
+`)
+}
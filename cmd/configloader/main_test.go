@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	configloader "github.com/wesleysantana/config-loader"
+)
+
+// cliConfig struct usada para gerar o schema dos testes
+type cliConfig struct {
+	Port     int    `env:"CLI_PORT,8080" min:"1" max:"65535"`
+	LogLevel string `env:"CLI_LOG_LEVEL,info" enum:"debug,info"`
+	Password string `env:"CLI_PASSWORD,required"`
+	Database struct {
+		Timeout time.Duration `env:"CLI_DB_TIMEOUT,5s"`
+		Hosts   []string      `env:"CLI_DB_HOSTS,a,b"`
+	}
+}
+
+// cliSource é o código-fonte equivalente a cliConfig
+const cliSource = `package config
+
+import (
+	"time"
+
+	cl "github.com/wesleysantana/config-loader"
+)
+
+type Database struct {
+	Timeout time.Duration ` + "`env:\"CLI_DB_TIMEOUT,5s\"`" + `
+	Hosts   []string      ` + "`env:\"CLI_DB_HOSTS,a,b\"`" + `
+}
+
+type Config struct {
+	Port     int             ` + "`env:\"CLI_PORT,8080\" min:\"1\" max:\"65535\"`" + `
+	LogLevel string          ` + "`env:\"CLI_LOG_LEVEL,info\" enum:\"debug,info\"`" + `
+	Password cl.SecretString ` + "`env:\"CLI_PASSWORD,required\"`" + `
+	Database Database
+	internal string
+}
+`
+
+// writeSchema grava o schema de cliConfig em um arquivo temporário
+func writeSchema(t *testing.T) string {
+	t.Helper()
+
+	data, err := configloader.Schema(cliConfig{})
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.schema.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+	return path
+}
+
+// writeSource grava cliSource em um pacote temporário
+func writeSource(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.go"), []byte(cliSource), 0o600); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+	return dir
+}
+
+// runCLI executa o comando e retorna o código de saída, stdout e stderr
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestValidate testa o comando validate com schema e com código-fonte
+func TestValidate(t *testing.T) {
+	inputs := map[string][]string{
+		"Schema": {"-schema", writeSchema(t)},
+		"Source": {"-pkg", writeSource(t), "-type", "Config"},
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			os.Unsetenv("CLI_PASSWORD")
+			os.Setenv("CLI_LOG_LEVEL", "verbose")
+			defer os.Unsetenv("CLI_LOG_LEVEL")
+
+			code, _, stderr := runCLI(append([]string{"validate"}, input...)...)
+			if code != 1 {
+				t.Errorf("Expected exit code 1, got %d", code)
+			}
+			for _, expected := range []string{"CLI_PASSWORD is required", `CLI_LOG_LEVEL must be one of [debug, info], got "verbose"`} {
+				if !strings.Contains(stderr, expected) {
+					t.Errorf("Expected %q in stderr, got: %s", expected, stderr)
+				}
+			}
+
+			os.Setenv("CLI_PASSWORD", "s3cr3t")
+			os.Setenv("CLI_LOG_LEVEL", "debug")
+			defer os.Unsetenv("CLI_PASSWORD")
+
+			code, stdout, stderr := runCLI(append([]string{"validate"}, input...)...)
+			if code != 0 || !strings.Contains(stdout, "configuration is valid") {
+				t.Errorf("Expected valid configuration, got code %d: %s%s", code, stdout, stderr)
+			}
+		})
+	}
+}
+
+// TestPrint testa o comando print
+func TestPrint(t *testing.T) {
+	os.Setenv("CLI_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("CLI_PASSWORD")
+
+	code, stdout, stderr := runCLI("print", "-pkg", writeSource(t), "-type", "Config", "-format", "json")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+
+	for _, expected := range []string{`"CLI_PORT": 8080`, `"CLI_PASSWORD": "***MASKED***"`, `"Database": {`, `"CLI_DB_TIMEOUT": "5s"`} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, stdout)
+		}
+	}
+	if strings.Contains(stdout, "s3cr3t") {
+		t.Errorf("Sensitive value leaked in output:\n%s", stdout)
+	}
+}
+
+// TestExample testa o comando example
+func TestExample(t *testing.T) {
+	code, stdout, stderr := runCLI("example", "-schema", writeSchema(t))
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+
+	for _, expected := range []string{"CLI_PORT=8080\n", "# Type: string | Required | Sensitive\nCLI_PASSWORD=\n", "# ===== Database =====\n"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, stdout)
+		}
+	}
+
+	code, stdout, stderr = runCLI("example", "-schema", writeSchema(t), "-format", "usage")
+	if code != 0 || !strings.Contains(stdout, "  CLI_PASSWORD string\n    \t(required; sensitive)\n") {
+		t.Errorf("Expected usage text, got code %d: %s%s", code, stdout, stderr)
+	}
+}
+
+// TestLint testa o comando lint com um arquivo com problemas e um arquivo válido
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.env")
+	good := filepath.Join(dir, "good.env")
+	os.WriteFile(bad, []byte("CLI_PORT=abc\nCLI_PROT=1\n"), 0o600)
+	os.WriteFile(good, []byte("CLI_PORT=9090\nCLI_DB_HOSTS=a,b\n"), 0o600)
+
+	code, stdout, _ := runCLI("lint", "-schema", writeSchema(t), "-env-file", bad)
+	if code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	for _, expected := range []string{bad + ":1: invalid value for CLI_PORT", bad + ":2: CLI_PROT is not used by the config (did you mean CLI_PORT?)"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected %q in stdout, got: %s", expected, stdout)
+		}
+	}
+
+	code, stdout, stderr := runCLI("lint", "-pkg", writeSource(t), "-type", "Config", "-env-file", good)
+	if code != 0 || !strings.Contains(stdout, "no issues found") {
+		t.Errorf("Expected no issues, got code %d: %s%s", code, stdout, stderr)
+	}
+}
+
+// TestUsageErrors testa erros de uso
+func TestUsageErrors(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"validate"},
+		{"validate", "-pkg", "."},
+		{"print", "-schema", "a.json", "-pkg", "."},
+	}
+
+	for _, args := range tests {
+		if code, _, _ := runCLI(args...); code != 2 {
+			t.Errorf("Expected exit code 2 for %v, got %d", args, code)
+		}
+	}
+
+	if code, _, stderr := runCLI("validate", "-pkg", writeSource(t), "-type", "Missing"); code != 1 || !strings.Contains(stderr, "type Missing not found") {
+		t.Errorf("Expected missing type error, got code %d: %s", code, stderr)
+	}
+
+	// Comandos desconhecidos são rejeitados antes de ler o schema ou o pacote
+	if code, _, stderr := runCLI("valdiate", "-schema", "missing.json"); code != 2 || !strings.Contains(stderr, `unknown command "valdiate"`) || strings.Contains(stderr, "missing.json") {
+		t.Errorf("Expected unknown command error, got code %d: %s", code, stderr)
+	}
+}
+
+// TestSchemaGroupConflict testa que um campo e um grupo x-field com o mesmo nome
+// são reportados como erro em vez de gerar uma struct inválida
+func TestSchemaGroupConflict(t *testing.T) {
+	tests := map[string][]configloader.NamedSchemaProperty{
+		"LeafFirst": {
+			{Name: "DB", Property: configloader.SchemaProperty{Type: "string", XField: "Db"}},
+			{Name: "DB_HOST", Property: configloader.SchemaProperty{Type: "string", XField: "Db.Host"}},
+		},
+		"GroupFirst": {
+			{Name: "DB_HOST", Property: configloader.SchemaProperty{Type: "string", XField: "Db.Host"}},
+			{Name: "DB", Property: configloader.SchemaProperty{Type: "string", XField: "Db"}},
+		},
+	}
+
+	for name, props := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := structFromSchema(configloader.SchemaDocument{Properties: props})
+			if err == nil || !strings.Contains(err.Error(), "conflicts with") {
+				t.Errorf("Expected conflict error, got %v", err)
+			}
+		})
+	}
+
+	// Segmentos normalizados para o mesmo nome exportado compartilham o grupo
+	typ, err := structFromSchema(configloader.SchemaDocument{Properties: []configloader.NamedSchemaProperty{
+		{Name: "A", Property: configloader.SchemaProperty{Type: "string", XField: "my-group.A"}},
+		{Name: "B", Property: configloader.SchemaProperty{Type: "string", XField: "my-group.B"}},
+	}})
+	if err != nil {
+		t.Fatalf("structFromSchema failed: %v", err)
+	}
+	if typ.NumField() != 1 || typ.Field(0).Type.NumField() != 2 {
+		t.Errorf("Expected one group with 2 fields, got %v", typ)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	configloader "github.com/wesleysantana/config-loader"
+	"github.com/wesleysantana/config-loader/internal/astconfig"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlPtrType   = reflect.TypeOf(&url.URL{})
+	stringsType  = reflect.TypeOf([]string{})
+	stringType   = reflect.TypeOf("")
+	int64Type    = reflect.TypeOf(int64(0))
+	float64Type  = reflect.TypeOf(float64(0))
+	boolType     = reflect.TypeOf(false)
+)
+
+// structFromSchema constrói, com reflect.StructOf, uma struct equivalente à descrita
+// por um JSON Schema exportado com configloader.Schema. As tags `env`, `enum`, `min`,
+// `max`, `desc` e `secret` são reconstruídas a partir das propriedades, e o caminho
+// x-field recria as structs aninhadas.
+func structFromSchema(doc configloader.SchemaDocument) (reflect.Type, error) {
+	required := make(map[string]bool)
+	for _, name := range doc.Required {
+		required[name] = true
+	}
+
+	root := &structNode{}
+	for _, prop := range doc.Properties {
+		p := prop.Property
+
+		t, err := schemaFieldType(p)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", prop.Name, err)
+		}
+
+		segments := strings.Split(p.XField, ".")
+		if p.XField == "" {
+			segments = []string{prop.Name}
+		}
+
+		node := root
+		for _, group := range segments[:len(segments)-1] {
+			if node, err = node.group(group); err != nil {
+				return nil, fmt.Errorf("property %s: %w", prop.Name, err)
+			}
+		}
+		err = node.add(reflect.StructField{
+			Name: segments[len(segments)-1],
+			Type: t,
+			Tag:  schemaFieldTag(prop.Name, p, required[prop.Name]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", prop.Name, err)
+		}
+	}
+
+	return root.build()
+}
+
+// schemaFieldType retorna o tipo Go de uma propriedade, pelo x-type ou pelo type do JSON Schema.
+func schemaFieldType(p configloader.SchemaProperty) (reflect.Type, error) {
+	switch p.XType {
+	case "duration":
+		return durationType, nil
+	case "url":
+		return urlPtrType, nil
+	case "list":
+		return stringsType, nil
+	case "int":
+		return int64Type, nil
+	case "float":
+		return float64Type, nil
+	case "bool":
+		return boolType, nil
+	case "string":
+		return stringType, nil
+	}
+
+	switch p.Type {
+	case "string":
+		return stringType, nil
+	case "integer":
+		return int64Type, nil
+	case "number":
+		return float64Type, nil
+	case "boolean":
+		return boolType, nil
+	case "array":
+		return stringsType, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", p.Type)
+	}
+}
+
+// schemaFieldTag reconstrói as tags de um campo a partir da propriedade do schema.
+func schemaFieldTag(name string, p configloader.SchemaProperty, required bool) reflect.StructTag {
+	env := name
+	if required {
+		env += ",required"
+	} else if p.Default != nil {
+		env += "," + schemaValueString(p.Default)
+	}
+
+	tags := []string{fmt.Sprintf("env:%s", strconv.Quote(env))}
+
+	enum := p.Enum
+	if p.Items != nil && len(p.Items.Enum) > 0 {
+		enum = p.Items.Enum
+	}
+	if len(enum) > 0 {
+		tags = append(tags, fmt.Sprintf("enum:%s", strconv.Quote(schemaValueString(enum))))
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value any
+	}{
+		{"min", p.Minimum}, {"max", p.Maximum},
+		{"min", p.MinLength}, {"max", p.MaxLength},
+		{"min", p.MinItems}, {"max", p.MaxItems},
+	} {
+		if v := reflect.ValueOf(bound.value); !v.IsNil() {
+			tags = append(tags, fmt.Sprintf("%s:%s", bound.name, strconv.Quote(fmt.Sprint(v.Elem().Interface()))))
+		}
+	}
+
+	if p.WriteOnly {
+		tags = append(tags, `secret:"true"`)
+	}
+	if p.Description != "" {
+		tags = append(tags, fmt.Sprintf("desc:%s", strconv.Quote(p.Description)))
+	}
+
+	return reflect.StructTag(strings.Join(tags, " "))
+}
+
+// schemaValueString converte um valor JSON (default ou enum) para o formato das tags.
+func schemaValueString(value any) string {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = schemaValueString(item)
+		}
+		return strings.Join(items, ",")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// structFromSource constrói, com reflect.StructOf, uma struct equivalente à struct
+// typeName declarada no pacote Go em pkg (diretório ou import path). Apenas os tipos
+// suportados pelo configloader são aceitos; Secret[T] vira T com a tag `secret:"true"`.
+func structFromSource(pkg, typeName string) (reflect.Type, error) {
+	dir, err := astconfig.Dir(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := astconfig.Parse(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := p.Struct(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	return astconfig.StructType(fields), nil
+}
+
+// structNode é um nível de struct em construção por structFromSchema.
+type structNode struct {
+	fields   []reflect.StructField
+	children []*structNode
+	name     string
+}
+
+// group retorna a struct aninhada com o nome informado, criando-a se necessário.
+// Retorna erro se já houver um campo comum com o mesmo nome.
+func (n *structNode) group(name string) (*structNode, error) {
+	name = exportedName(name)
+	for _, child := range n.children {
+		if child.name == name {
+			return child, nil
+		}
+	}
+	if n.hasField(name) {
+		return nil, fmt.Errorf("x-field group %s conflicts with a field of the same name", name)
+	}
+	child := &structNode{name: name}
+	n.children = append(n.children, child)
+	n.fields = append(n.fields, reflect.StructField{Name: child.name})
+	return child, nil
+}
+
+// add acrescenta um campo, garantindo um nome exportado e único. Retorna erro se já
+// houver uma struct aninhada com o mesmo nome.
+func (n *structNode) add(field reflect.StructField) error {
+	field.Name = exportedName(field.Name)
+	for _, child := range n.children {
+		if child.name == field.Name {
+			return fmt.Errorf("field %s conflicts with an x-field group of the same name", field.Name)
+		}
+	}
+	for n.hasField(field.Name) {
+		field.Name += "_"
+	}
+	n.fields = append(n.fields, field)
+	return nil
+}
+
+// hasField indica se já existe um campo com o nome informado.
+func (n *structNode) hasField(name string) bool {
+	for _, f := range n.fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// build converte a árvore em um reflect.Type, resolvendo as structs aninhadas.
+func (n *structNode) build() (reflect.Type, error) {
+	fields := make([]reflect.StructField, len(n.fields))
+	copy(fields, n.fields)
+
+	for i, f := range fields {
+		if f.Type != nil {
+			continue
+		}
+		for _, child := range n.children {
+			if child.name == f.Name {
+				t, err := child.build()
+				if err != nil {
+					return nil, err
+				}
+				fields[i].Type = t
+			}
+		}
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// exportedName converte um nome qualquer em um identificador Go exportado.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+
+	result := b.String()
+	if result == "" || !unicode.IsUpper([]rune(result)[0]) {
+		result = "F" + result
+	}
+	return result
+}
@@ -1,26 +1,92 @@
 package configloader
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"reflect"
-	"strconv"
 	"strings"
-	"time"
-
-	"github.com/joho/godotenv"
 )
 
 // LoadOptions configura o comportamento do carregamento de variáveis de ambiente.
 // Use esta struct para personalizar como as variáveis são carregadas.
 type LoadOptions struct {
 	// EnvFiles especifica os caminhos para arquivos .env a serem carregados.
+	// O último arquivo tem precedência sobre os anteriores.
 	// Se vazio, a biblioteca tentará carregar de locais comuns.
 	EnvFiles []string
 
 	// UseSystem determina se variáveis de ambiente do sistema devem ser usadas.
 	// Padrão: true. Se false, apenas arquivos .env serão considerados.
 	UseSystem bool
+
+	// Provenance, se não for nil, recebe a origem de cada campo carregado
+	// (variável do sistema, arquivo .env e linha, ou default da tag).
+	Provenance *Provenance
+
+	// Trace, se não for nil, recebe um log detalhado de cada etapa da resolução:
+	// arquivos tentados, fontes consultadas para cada variável, defaults aplicados
+	// e valores rejeitados. Valores de campos sensíveis são mascarados.
+	Trace io.Writer
+
+	// Logger, se não for nil, recebe logs estruturados sobre arquivos .env carregados,
+	// ignorados ou ausentes, valores resolvidos, nomes obsoletos usados (tag
+	// `deprecated`) e resultados de Reload. Valores de campos sensíveis são mascarados.
+	Logger *slog.Logger
+
+	// Mask configura o mascaramento de valores sensíveis em Trace e Logger.
+	Mask MaskOptions
+
+	// Prefix é prefixado ao nome de todas as variáveis (ex: "APP_" lê APP_DB_HOST
+	// para a tag `env:"DB_HOST"`).
+	Prefix string
+
+	// Sources são consultadas, na ordem, quando a variável não está definida no ambiente.
+	Sources []Source
+
+	// Profile é o perfil de ambiente (ex: "development", "production"). Com um perfil,
+	// cada arquivo .env (ou ".env", se EnvFiles estiver vazio) é carregado em camadas,
+	// da menor para a maior precedência: .env, .env.<perfil>, .env.local e
+	// .env.<perfil>.local. As variantes que não existirem são ignoradas.
+	// Se vazio, é usada a variável de ambiente APP_ENV.
+	Profile string
+
+	// Discovery, se não for nil e EnvFiles estiver vazio, procura os arquivos .env
+	// subindo pelos diretórios pais até a raiz do projeto (ver DiscoveryOptions).
+	Discovery *DiscoveryOptions
+
+	// LoadedFiles, se não for nil, recebe os caminhos dos arquivos .env efetivamente
+	// carregados, na ordem de carregamento.
+	LoadedFiles *[]string
+
+	// ConfigFiles são arquivos de configuração estruturados (.yaml, .yml, .json, .toml), consultados
+	// depois do ambiente e dos arquivos .env e antes de Sources. O último arquivo tem
+	// precedência. Ver FileSource.
+	ConfigFiles []string
+
+	// Flags são os flags de linha de comando registrados com RegisterFlags. Os flags
+	// informados têm precedência sobre todas as outras fontes.
+	Flags *FlagSource
+
+	// Strict verifica variáveis desconhecidas: chaves dos arquivos .env e, com Prefix,
+	// variáveis do ambiente com o prefixo que não correspondem a nenhuma tag `env`.
+	Strict StrictMode
+
+	// Validate rejeita valores que violam as tags `enum`, `min` e `max`. Sem ele, essas
+	// tags apenas documentam os valores aceitos (Usage, Schema, GenerateEnvExample).
+	Validate bool
+}
+
+// resolveOptions aplica as opções sobre os padrões (UseSystem: true).
+func resolveOptions(opts []Option) LoadOptions {
+	options := LoadOptions{UseSystem: true}
+	for _, opt := range opts {
+		opt.applyOption(&options)
+	}
+	options.Mask = options.Mask.normalized()
+	return options
 }
 
 // Load carrega configurações a partir de variáveis de ambiente e arquivos .env.
@@ -29,7 +95,7 @@ type LoadOptions struct {
 //
 // Parâmetros:
 //   - config: Ponteiro para uma struct com tags `env` para mapeamento
-//   - opts: Opções de carregamento (opcional)
+//   - opts: Opções de carregamento (opcional): LoadOptions ou funções With*
 //
 // Exemplo:
 //
@@ -46,26 +112,18 @@ type LoadOptions struct {
 //
 // Retorna:
 //   - error: Erro se a validação falhar ou se ocorrer problema no carregamento
-func Load(config any, opts ...LoadOptions) error {
-	options := LoadOptions{
-		UseSystem: true,
-	}
+func Load(config any, opts ...Option) error {
+	options := resolveOptions(opts)
+	l := newLoader(options)
 
-	if len(opts) > 0 {
-		options = opts[0]
+	if err := l.loadFiles(options); err != nil {
+		return err
 	}
-
-	// Carrega arquivos .env se especificados
-	if len(options.EnvFiles) > 0 {
-		if err := godotenv.Load(options.EnvFiles...); err != nil {
-			return fmt.Errorf("error loading .env files: %w", err)
-		}
-	} else {
-		// Tenta carregar .env na raiz, mas não falha se não existir
-		godotenv.Load()
+	if err := l.loadConfigFiles(options.ConfigFiles); err != nil {
+		return err
 	}
 
-	return loadFromEnv(config, options.UseSystem)
+	return l.load(config)
 }
 
 // MustLoad carrega configurações e entra em panic se qualquer campo required estiver faltando.
@@ -87,6 +145,51 @@ func MustLoad(config any) {
 	}
 }
 
+// LoadAs carrega as configurações em um novo valor do tipo T e o retorna, sem que
+// seja preciso declarar a variável e passar um ponteiro. Usa as mesmas regras de Load.
+//
+// Como generics em Go não permitem restringir T a structs, um T que não seja struct
+// é rejeitado antes de qualquer carregamento (nenhum arquivo .env é lido).
+//
+// Parâmetros:
+//   - opts: Opções de carregamento (opcional)
+//
+// Exemplo:
+//
+//	cfg, err := LoadAs[Config]()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Retorna:
+//   - T: Configurações carregadas
+//   - error: Erro se T não for uma struct, se a validação falhar ou se ocorrer problema no carregamento
+func LoadAs[T any](opts ...Option) (T, error) {
+	var config T
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Struct {
+		return config, fmt.Errorf("type parameter must be a struct, got %s", t)
+	}
+
+	err := Load(&config, opts...)
+	return config, err
+}
+
+// MustLoadAs funciona como LoadAs, mas entra em panic em caso de erro.
+//
+// Panics:
+//   - Se T não for uma struct ou se a carga falhar (ex: campo required ausente)
+//
+// Exemplo:
+//
+//	var cfg = MustLoadAs[Config]()
+func MustLoadAs[T any](opts ...Option) T {
+	config, err := LoadAs[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return config
+}
+
 // LoadFromEnv carrega configurações apenas a partir de variáveis de ambiente do sistema,
 // ignorando completamente arquivos .env.
 //
@@ -100,7 +203,7 @@ func MustLoad(config any) {
 //
 //	err := LoadFromEnv(&cfg) // Apenas variáveis de sistema
 func LoadFromEnv(config any) error {
-	return loadFromEnv(config, true)
+	return newLoader(resolveOptions(nil)).load(config)
 }
 
 // LoadFromFile carrega configurações a partir de um arquivo .env específico.
@@ -117,15 +220,17 @@ func LoadFromEnv(config any) error {
 //
 //	err := LoadFromFile(&cfg, "config/production.env")
 func LoadFromFile(config any, envFile string) error {
-	if err := godotenv.Load(envFile); err != nil {
+	l := newLoader(resolveOptions(nil))
+	if err := l.loadEnvFiles(envFile); err != nil {
 		return fmt.Errorf("error loading .env file: %w", err)
 	}
-	return loadFromEnv(config, true)
+	return l.load(config)
 }
 
 // LoadFromFiles carrega configurações a partir de múltiplos arquivos .env.
 // Útil para separar configurações em diferentes arquivos (ex: .env.base, .env.secrets).
-// O último arquivo na lista tem precedência (override).
+// O último arquivo na lista tem precedência (override) sobre os anteriores; variáveis
+// do sistema têm precedência sobre todos os arquivos.
 //
 // Parâmetros:
 //   - config: Ponteiro para uma struct com tags `env`
@@ -138,16 +243,21 @@ func LoadFromFile(config any, envFile string) error {
 //
 //	err := LoadFromFiles(&cfg, ".env.defaults", ".env.local")
 func LoadFromFiles(config any, envFiles ...string) error {
-	if err := godotenv.Load(envFiles...); err != nil {
+	l := newLoader(resolveOptions(nil))
+	if err := l.loadEnvFiles(envFiles...); err != nil {
 		return fmt.Errorf("error loading .env files: %w", err)
 	}
-	return loadFromEnv(config, true)
+	return l.load(config)
 }
 
-// FindAndLoad procura automaticamente por arquivos .env em locais comuns
-// e carrega as configurações. Útil quando não se sabe o local exato do arquivo.
+// FindAndLoad procura automaticamente por arquivos .env e carrega as configurações.
+// Útil quando não se sabe o local exato do arquivo.
 //
-// Locais pesquisados:
+// Com WithDiscovery, a busca sobe pelos diretórios pais até a raiz do projeto
+// (go.mod, .git), com nomes e diretórios iniciais configuráveis, e pode carregar
+// todos os arquivos encontrados em camadas (ver DiscoveryOptions).
+//
+// Sem WithDiscovery, são pesquisados os locais:
 //   - .env (raiz do projeto)
 //   - ./.env
 //   - ../.env (um nível acima)
@@ -156,8 +266,14 @@ func LoadFromFiles(config any, envFiles ...string) error {
 //   - ./env/.env
 //   - caminho da variável de ambiente ENV_FILE
 //
+// e apenas o primeiro arquivo encontrado é carregado. Em ambos os casos, com um perfil
+// (WithProfile ou APP_ENV) cada arquivo é carregado em camadas como em Load. Use
+// LoadOptions.Trace para ver quais caminhos foram tentados e WithLoadedFiles para
+// saber quais foram usados.
+//
 // Parâmetros:
 //   - config: Ponteiro para uma struct com tags `env`
+//   - opts: Opções de carregamento (opcional); EnvFiles é ignorado
 //
 // Retorna:
 //   - error: Erro se a validação falhar
@@ -165,7 +281,25 @@ func LoadFromFiles(config any, envFiles ...string) error {
 // Exemplo:
 //
 //	err := FindAndLoad(&cfg) // Busca automática
-func FindAndLoad(config any) error {
+//
+//	var used []string
+//	err = FindAndLoad(&cfg, WithDiscovery(DiscoveryOptions{All: true}), WithLoadedFiles(&used))
+func FindAndLoad(config any, opts ...Option) error {
+	options := resolveOptions(opts)
+	options.EnvFiles = nil
+	l := newLoader(options)
+
+	if err := l.loadConfigFiles(options.ConfigFiles); err != nil {
+		return err
+	}
+
+	if options.Discovery != nil {
+		if err := l.loadFiles(options); err != nil {
+			return err
+		}
+		return l.load(config)
+	}
+
 	possiblePaths := []string{
 		".env",
 		"./.env",
@@ -176,26 +310,199 @@ func FindAndLoad(config any) error {
 		os.Getenv("ENV_FILE"), // Permite override por variável de ambiente
 	}
 
+	profile := resolveProfile(options)
+	if profile != "" {
+		l.tracef("using profile %q", profile)
+	}
+
+	found := false
 	for _, path := range possiblePaths {
 		if path == "" {
 			continue
 		}
-		if _, err := os.Stat(path); err == nil {
-			if err := godotenv.Load(path); err == nil {
-				break
-			}
+		if _, err := os.Stat(path); err != nil {
+			l.tracef("trying %s: not found", path)
+			continue
 		}
+		loaded, err := l.loadEnvLayers(profileLayers([]string{path}, profile, false))
+		if err != nil {
+			l.tracef("trying %s: failed to load: %v", path, err)
+			continue
+		}
+		l.tracef("trying %s: loaded", path)
+		l.logger.Info("found .env file", "path", path)
+		reportLoaded(options, loaded)
+		found = true
+		break
+	}
+
+	if !found {
+		l.tracef("no .env file found, using system environment only")
+		l.logger.Info("no .env file found, using system environment only")
+		reportLoaded(options, nil)
 	}
 
 	// Continua com variáveis de sistema mesmo se não encontrou arquivo
-	return loadFromEnv(config, true)
+	return l.load(config)
+}
+
+// resolveProfile retorna o perfil de LoadOptions.Profile ou, se vazio, de APP_ENV.
+func resolveProfile(options LoadOptions) string {
+	if options.Profile != "" {
+		return options.Profile
+	}
+	return os.Getenv("APP_ENV")
+}
+
+// loadFiles carrega os arquivos .env das opções: os de EnvFiles (obrigatórios), os
+// encontrados por Discovery ou, sem nenhum dos dois, ".env" do diretório atual se
+// existir. Com um perfil (Profile ou APP_ENV), cada arquivo é expandido em camadas.
+func (l *loader) loadFiles(options LoadOptions) error {
+	profile := resolveProfile(options)
+	if profile != "" {
+		l.tracef("using profile %q", profile)
+	}
+
+	// Carrega arquivos .env se especificados
+	if len(options.EnvFiles) > 0 {
+		l.tracef("loading .env files: %s", strings.Join(options.EnvFiles, ", "))
+		loaded, err := l.loadEnvLayers(profileLayers(options.EnvFiles, profile, false))
+		if err != nil {
+			l.tracef("failed to load .env files: %v", err)
+			return fmt.Errorf("error loading .env files: %w", err)
+		}
+		reportLoaded(options, loaded)
+		return nil
+	}
+
+	if options.Discovery != nil {
+		files, err := discoverEnvFiles(*options.Discovery, l.tracef)
+		if err != nil {
+			return fmt.Errorf("error discovering .env files: %w", err)
+		}
+		if len(files) == 0 {
+			l.tracef("no .env file found, using system environment only")
+			l.logger.Info("no .env file found, using system environment only")
+			reportLoaded(options, nil)
+			return nil
+		}
+
+		loaded, err := l.loadEnvLayers(profileLayers(files, profile, false))
+		if err != nil {
+			return fmt.Errorf("error loading .env files: %w", err)
+		}
+		l.tracef("loaded: %s", strings.Join(loaded, ", "))
+		l.logger.Info("found .env files", "paths", loaded)
+		reportLoaded(options, loaded)
+		return nil
+	}
+
+	// Tenta carregar .env na raiz, mas não falha se não existir
+	loaded, err := l.loadEnvLayers(profileLayers([]string{".env"}, profile, true))
+	if err != nil {
+		l.tracef("no .env file loaded from working directory: %v", err)
+	} else {
+		l.tracef("loaded from working directory: %s", strings.Join(loaded, ", "))
+	}
+	reportLoaded(options, loaded)
+	return nil
+}
+
+// lookupDeprecated procura no ambiente os nomes antigos do campo (tag `deprecated`), na
+// ordem da tag, e registra um aviso no Logger quando um deles é usado. A origem
+// mantém o nome atual da variável, para que Provenance.Lookup continue funcionando.
+func (l *loader) lookupDeprecated(fi fieldInfo, envName string) (string, Origin, bool) {
+	for _, old := range fi.deprecatedNames() {
+		name := l.prefix + old
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		origin := envOrigin(name, fi.path(), value)
+		origin.Name = envName
+		if l.trace != nil {
+			l.tracef("%s: found deprecated name %s in environment (%s): %s", envName, name, origin, l.mask.traceValue(fi, value))
+		}
+		l.logger.Warn("deprecated config name used", "name", name, "replacement", envName)
+		return value, origin, true
+	}
+	return "", Origin{}, false
+}
+
+// lookupSources consulta as Sources na ordem e retorna o primeiro valor não vazio e
+// a sua origem. Sources que resolvem o campo pelas tags da struct (FileSource,
+// VaultSource) podem falhar; o erro interrompe a busca.
+func (l *loader) lookupSources(fi fieldInfo, envName string, origin Origin) (string, []string, Origin, error) {
+	for _, src := range l.sources {
+		if fs, ok := src.(fieldSource); ok {
+			v, found, err := fs.lookupField(fi)
+			if err != nil {
+				return "", nil, origin, err
+			}
+			if found.Kind != "" && v != "" {
+				found.Name, found.Field, found.Raw = envName, fi.path(), v
+				if l.trace != nil && found.Kind == OriginFile {
+					l.tracef("%s: found in config file (%s): %s", envName, found, l.mask.traceValue(fi, v))
+				} else if l.trace != nil {
+					l.tracef("%s: found in source %s: %s", envName, found, l.mask.traceValue(fi, v))
+				}
+				var items []string
+				if ls, ok := src.(listSource); ok {
+					items, _ = ls.lookupItems(fi)
+				}
+				return v, items, found, nil
+			}
+			continue
+		}
+
+		if v, ok := src.Lookup(envName); ok && v != "" {
+			found := Origin{Name: envName, Field: fi.path(), Kind: OriginSource, File: sourceName(src), Raw: v}
+			if l.trace != nil {
+				l.tracef("%s: found in source %s: %s", envName, found.File, l.mask.traceValue(fi, v))
+			}
+			return v, nil, found, nil
+		}
+	}
+	return "", nil, origin, nil
+}
+
+// resolveReferences substitui um valor que seja uma referência (ex: vault://caminho#chave)
+// pelo valor resolvido por uma das Sources. Valores que nenhuma Source reconhece são
+// mantidos.
+func (l *loader) resolveReferences(fi fieldInfo, envName, value string) (string, error) {
+	for _, src := range l.sources {
+		r, ok := src.(referenceResolver)
+		if !ok {
+			continue
+		}
+		resolved, ok, err := r.resolveReference(value)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			if l.trace != nil {
+				l.tracef("%s: resolved reference %s: %s", envName, value, l.mask.traceValue(fi, resolved))
+			}
+			return resolved, nil
+		}
+	}
+	return value, nil
+}
+
+// reportLoaded registra em LoadOptions.LoadedFiles os arquivos carregados, se configurado.
+func reportLoaded(options LoadOptions, loaded []string) {
+	if options.LoadedFiles != nil {
+		*options.LoadedFiles = loaded
+	}
 }
 
 // SPrint retorna uma representação string formatada das configurações carregadas.
-// Campos sensíveis (com palavras como password, secret, key) são mascarados.
+// Campos sensíveis (tag `secret`/`mask` ou palavras como password, secret, key) são mascarados.
 //
 // Parâmetros:
 //   - config: Struct com as configurações carregadas
+//   - opts: Opções de mascaramento (opcional)
 //
 // Retorna:
 //   - string: Configurações formatadas para visualização
@@ -208,90 +515,206 @@ func FindAndLoad(config any) error {
 //	// ==========================
 //	// SERVER_PORT: 8080
 //	// DB_PASSWORD: ***MASKED***
-func SPrint(config any) string {
+func SPrint(config any, opts ...MaskOptions) string {
+	mask := resolveMaskOptions(opts)
 	var result strings.Builder
 	v := reflect.ValueOf(config)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
-	t := v.Type()
-
 	result.WriteString("Environment Configuration:\n")
 	result.WriteString("==========================\n")
 
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-		envTag := field.Tag.Get("env")
-		if envTag == "" {
-			continue
-		}
+	for _, fi := range structFields(v.Type()) {
+		result.WriteString(fmt.Sprintf("%-20s: %s\n", fi.name, mask.displayValue(fi, v.FieldByIndex(fi.index))))
+	}
 
-		envName := strings.Split(envTag, ",")[0]
-		fieldValue := v.Field(i)
+	return result.String()
+}
 
-		// Esconde valores sensíveis
-		displayValue := fieldValue.Interface()
-		if shouldMaskField(field.Name) {
-			displayValue = "***MASKED***"
-		}
+// loader reúne o estado de uma carga: quais fontes consultar e o que registrar.
+type loader struct {
+	useSystem  bool
+	prefix     string
+	sources    []Source
+	strict     StrictMode
+	flags      *FlagSource
+	fileKeys   map[string]fileOrigin
+	provenance *Provenance
+	trace      io.Writer
+	logger     *slog.Logger
+	mask       MaskOptions
+	validate   bool
+}
 
-		result.WriteString(fmt.Sprintf("%-20s: %v\n", envName, displayValue))
+// newLoader cria um loader a partir das opções de carregamento.
+func newLoader(options LoadOptions) *loader {
+	l := &loader{
+		useSystem:  options.UseSystem,
+		prefix:     options.Prefix,
+		sources:    options.Sources,
+		strict:     options.Strict,
+		flags:      options.Flags,
+		provenance: options.Provenance,
+		trace:      options.Trace,
+		logger:     options.Logger,
+		mask:       options.Mask,
+		validate:   options.Validate,
+	}
+	if l.logger == nil {
+		l.logger = slog.New(slog.DiscardHandler)
 	}
+	return l
+}
 
-	return result.String()
+// tracef escreve uma linha no Trace, se configurado. Chamadas cujos argumentos
+// custam a calcular (ex: traceValue) devem verificar l.trace antes, pois os
+// argumentos são avaliados mesmo sem Trace.
+func (l *loader) tracef(format string, args ...any) {
+	if l.trace == nil {
+		return
+	}
+	fmt.Fprintf(l.trace, "configloader: "+format+"\n", args...)
 }
 
-// loadFromEnv é a função interna que realiza o carregamento das variáveis de ambiente
-// para a struct configurada.
-func loadFromEnv(config any, useSystem bool) error {
+// load resolve cada campo com tag `env` da struct apontada por config.
+func (l *loader) load(config any) error {
 	v := reflect.ValueOf(config)
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("config must be a pointer to a struct")
 	}
 
 	v = v.Elem()
-	t := v.Type()
 
-	var validationErrors []string
+	if l.provenance != nil {
+		*l.provenance = nil
+	}
 
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-		envTag := field.Tag.Get("env")
-		if envTag == "" {
-			continue
-		}
+	var validationErrors []string
 
-		parts := parseEnvTag(envTag)
-		envName := parts[0]
+	fields := structFields(v.Type())
+	for _, fi := range fields {
+		parts := fi.tag
+		envName := l.prefix + fi.name
+		fieldValue := v.FieldByIndex(fi.index)
 
 		value := ""
-		if useSystem {
+		origin := Origin{Name: envName, Field: fi.path(), Kind: OriginUnset}
+		if l.flags != nil {
+			if v, flagName, ok := l.flags.lookupField(fi); ok && v != "" {
+				value = v
+				origin = Origin{Name: envName, Field: fi.path(), Kind: OriginFlag, File: "--" + flagName, Raw: v}
+				if l.trace != nil {
+					l.tracef("%s: found in flag --%s: %s", envName, flagName, l.mask.traceValue(fi, value))
+				}
+			} else if ok {
+				l.tracef("%s: flag --%s is empty, treated as not set", envName, flagName)
+			}
+		}
+
+		// Flags têm precedência sobre todas as outras fontes.
+		switch {
+		case value != "":
+		case l.useSystem:
 			value = os.Getenv(envName)
+			if value != "" {
+				origin = envOrigin(envName, fi.path(), value)
+				if l.trace != nil {
+					l.tracef("%s: found in environment (%s): %s", envName, origin, l.mask.traceValue(fi, value))
+				}
+			} else if v, found, ok := l.lookupDeprecated(fi, envName); ok {
+				value, origin = v, found
+			} else {
+				l.tracef("%s: not set in environment", envName)
+			}
+		default:
+			l.tracef("%s: system environment disabled, skipping lookup", envName)
+		}
+
+		// items são os itens de um array de arquivo de configuração, se for o caso.
+		var items []string
+		if value == "" {
+			var err error
+			if value, items, origin, err = l.lookupSources(fi, envName, origin); err != nil {
+				l.tracef("%s: lookup failed: %v", envName, err)
+				validationErrors = append(validationErrors, fmt.Sprintf("%s: %v", envName, err))
+				continue
+			}
+		}
+
+		if value != "" && items == nil {
+			resolved, err := l.resolveReferences(fi, envName, value)
+			if err != nil {
+				l.tracef("%s: reference not resolved: %v", envName, err)
+				validationErrors = append(validationErrors, fmt.Sprintf("%s: %v", envName, err))
+				continue
+			}
+			value = resolved
 		}
 
 		// Lógica de default/required - agora parts[1] contém o valor completo
 		if value == "" && len(parts) > 1 {
 			defaultValue := parts[1]
 			if defaultValue == "required" {
+				l.tracef("%s: no value and field is required", envName)
 				validationErrors = append(validationErrors, fmt.Sprintf("%s is required", envName))
 			} else {
 				value = defaultValue // Usa o valor default completo
+				origin.Kind = OriginDefault
+				origin.Raw = value
+				if l.trace != nil {
+					l.tracef("%s: using tag default: %s", envName, l.mask.traceValue(fi, value))
+				}
 			}
+		} else if value == "" {
+			l.tracef("%s: no value and no default, field left unchanged", envName)
+		}
+
+		if l.provenance != nil {
+			*l.provenance = append(*l.provenance, origin)
 		}
 
-		if value != "" && v.Field(i).CanSet() {
-			if err := setFieldValue(v.Field(i), value); err != nil {
-				return fmt.Errorf("error setting field %s: %w", field.Name, err)
+		if value != "" && fieldValue.CanSet() {
+			if err := setFieldItems(fieldValue, value, items); err != nil {
+				if l.trace != nil {
+					l.tracef("%s: value %s rejected: %v", envName, l.mask.traceValue(fi, value), err)
+				}
+				l.logger.Error("invalid config value", "name", envName, "value", l.mask.traceValue(fi, value), "error", err)
+				return fmt.Errorf("error setting field %s: %w", fi.path(), err)
+			}
+			if l.logger.Enabled(context.Background(), slog.LevelDebug) {
+				l.logger.Debug("config value resolved", "name", envName, "source", origin.String(), "value", l.mask.displayValue(fi, fieldValue))
+			}
+
+			if l.validate {
+				constrained := fi
+				constrained.name = envName
+				if err := checkConstraints(constrained, fieldValue, l.mask.isSensitiveField(fi)); err != nil {
+					if l.trace != nil {
+						l.tracef("%s: value %s rejected: %v", envName, l.mask.traceValue(fi, value), err)
+					}
+					validationErrors = append(validationErrors, err.Error())
+				}
 			}
 		}
 	}
 
+	unknownErr := l.checkUnknown(fields)
+
 	if len(validationErrors) > 0 {
+		l.logger.Error("config validation failed", "errors", validationErrors)
+		if unknownErr != nil {
+			// Variáveis desconhecidas costumam explicar campos required ausentes (erros de digitação).
+			return fmt.Errorf("validation errors: %s; %w", strings.Join(validationErrors, "; "), unknownErr)
+		}
 		return fmt.Errorf("validation errors: %s", strings.Join(validationErrors, "; "))
 	}
 
-	return nil
+	if unknownErr != nil {
+		l.logger.Error("config validation failed", "error", unknownErr)
+	}
+	return unknownErr
 }
 
 // parseEnvTag parseia a tag `env` extraindo o nome da variável e valores default.
@@ -309,33 +732,52 @@ func parseEnvTag(tag string) []string {
 }
 
 // setFieldValue define o valor de um campo baseado no seu tipo e no valor string fornecido.
-// Suporta: string, int, bool, []string, time.Duration, float64
+// Suporta: string, int, bool, []string, time.Duration, float64, url.URL, *url.URL
+// e Secret[T] desses tipos
 func setFieldValue(field reflect.Value, value string) error {
+	if field.CanAddr() && isSecretType(field.Type()) {
+		return field.Addr().Interface().(secretSetter).setSecret(value)
+	}
+
 	// Verifica primeiro se é time.Duration (que é um tipo alias de int64)
-	if field.Type() == reflect.TypeOf(time.Duration(0)) {
-		duration, err := time.ParseDuration(value)
+	if field.Type() == durationType {
+		duration, err := ParseDuration(value)
 		if err != nil {
-			return fmt.Errorf("invalid duration value '%s': %w", value, err)
+			return err
 		}
 		field.SetInt(int64(duration))
 		return nil
 	}
 
+	// url.URL e *url.URL são convertidos com url.Parse
+	if field.Type() == urlType || field.Type() == urlPtrType {
+		u, err := ParseURL(value)
+		if err != nil {
+			return err
+		}
+		if field.Kind() == reflect.Ptr {
+			field.Set(reflect.ValueOf(u))
+		} else {
+			field.Set(reflect.ValueOf(*u))
+		}
+		return nil
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		intValue, err := strconv.ParseInt(value, 10, 64)
+		intValue, err := ParseInt(value)
 		if err != nil {
-			return fmt.Errorf("invalid integer value '%s': %w", value, err)
+			return err
 		}
 		field.SetInt(intValue)
 
 	case reflect.Bool:
-		boolValue, err := parseBool(value)
+		boolValue, err := ParseBool(value)
 		if err != nil {
-			return fmt.Errorf("invalid boolean value '%s': %w", value, err)
+			return err
 		}
 		field.SetBool(boolValue)
 
@@ -348,9 +790,9 @@ func setFieldValue(field reflect.Value, value string) error {
 		}
 
 	case reflect.Float32, reflect.Float64:
-		floatValue, err := strconv.ParseFloat(value, 64)
+		floatValue, err := ParseFloat(value)
 		if err != nil {
-			return fmt.Errorf("invalid float value '%s': %w", value, err)
+			return err
 		}
 		field.SetFloat(floatValue)
 
@@ -361,6 +803,22 @@ func setFieldValue(field reflect.Value, value string) error {
 	return nil
 }
 
+// setFieldItems define o campo com os itens de um array de arquivo de configuração,
+// sem dividi-los por vírgula, se o campo for uma lista de strings. Sem itens, ou
+// para outros tipos, usa setFieldValue com o valor unido por vírgula.
+func setFieldItems(field reflect.Value, value string, items []string) error {
+	if items == nil || field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.String {
+		return setFieldValue(field, value)
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+	for i, item := range items {
+		slice.Index(i).SetString(item)
+	}
+	field.Set(slice)
+	return nil
+}
+
 // parseBool converte uma string para valor booleano.
 // Aceita: "true", "1", "yes", "on", "t" → true
 //
@@ -396,20 +854,3 @@ func parseStringSlice(value string) []string {
 
 	return result
 }
-
-// shouldMaskField determina se um campo deve ser mascarado na exibição.
-// Campos com nomes contendo: password, secret, key, token, credential, auth, pass, pwd, access, private
-func shouldMaskField(fieldName string) bool {
-	maskedKeywords := []string{
-		"password", "secret", "key", "token", "credential",
-		"auth", "pass", "pwd", "access", "private",
-	}
-
-	lowerName := strings.ToLower(fieldName)
-	for _, keyword := range maskedKeywords {
-		if strings.Contains(lowerName, keyword) {
-			return true
-		}
-	}
-	return false
-}
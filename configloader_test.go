@@ -1,7 +1,9 @@
 package configloader
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -218,6 +220,61 @@ func TestMustLoad(t *testing.T) {
 	MustLoad(&cfg)
 }
 
+// TestLoadAs testa o carregamento genérico retornando o valor
+func TestLoadAs(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "test123")
+	t.Setenv("MAX_USERS", "42")
+
+	cfg, err := LoadAs[TestConfig]()
+	if err != nil {
+		t.Fatalf("LoadAs failed: %v", err)
+	}
+
+	if cfg.DBPassword != "test123" {
+		t.Errorf("Expected DBPassword test123, got %s", cfg.DBPassword)
+	}
+
+	if cfg.MaxUsers != 42 {
+		t.Errorf("Expected MaxUsers 42, got %d", cfg.MaxUsers)
+	}
+
+	if cfg.ServerPort != "8080" {
+		t.Errorf("Expected ServerPort 8080, got %s", cfg.ServerPort)
+	}
+}
+
+// TestLoadAs_Errors testa erros de validação e tipos que não são struct
+func TestLoadAs_Errors(t *testing.T) {
+	os.Unsetenv("DB_PASSWORD")
+
+	if _, err := LoadAs[TestConfig](LoadOptions{UseSystem: true}); err == nil || !strings.Contains(err.Error(), "DB_PASSWORD is required") {
+		t.Errorf("Expected required error, got %v", err)
+	}
+
+	if _, err := LoadAs[*TestConfig](); err == nil || err.Error() != "type parameter must be a struct, got *configloader.TestConfig" {
+		t.Errorf("Expected struct error for pointer type, got %v", err)
+	}
+
+	if _, err := LoadAs[string](); err == nil || !strings.Contains(err.Error(), "must be a struct") {
+		t.Errorf("Expected struct error for string, got %v", err)
+	}
+}
+
+// TestMustLoadAs testa MustLoadAs com e sem panic
+func TestMustLoadAs(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "test123")
+	if cfg := MustLoadAs[TestConfig](); cfg.DBPassword != "test123" {
+		t.Errorf("Expected DBPassword test123, got %s", cfg.DBPassword)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic from MustLoadAs, but none occurred")
+		}
+	}()
+	MustLoadAs[int]()
+}
+
 // TestSPrint testa a função de impressão
 func TestSPrint(t *testing.T) {
 	os.Setenv("DB_PASSWORD", "secret123")
@@ -422,3 +479,122 @@ func TestParseEnvTag(t *testing.T) {
 		})
 	}
 }
+
+// LayerConfig struct para testes de camadas de arquivos .env
+type LayerConfig struct {
+	Host  string `env:"LAYER_HOST"`
+	Port  string `env:"LAYER_PORT"`
+	Mode  string `env:"LAYER_MODE"`
+	Debug string `env:"LAYER_DEBUG"`
+}
+
+// layerKeys são as variáveis definidas pelos arquivos dos testes de camadas
+var layerKeys = []string{"LAYER_HOST", "LAYER_PORT", "LAYER_MODE", "LAYER_DEBUG"}
+
+// TestLoadFromFiles_LastWins testa que o último arquivo tem precedência
+func TestLoadFromFiles_LastWins(t *testing.T) {
+	base := writeEnvFile(t, "base.env", "LAYER_HOST=base.local\nLAYER_PORT=8080\n", layerKeys...)
+	override := writeEnvFile(t, "override.env", "LAYER_HOST=override.local\n", layerKeys...)
+
+	var prov Provenance
+	var cfg LayerConfig
+	if err := LoadFromFiles(&cfg, base, override); err != nil {
+		t.Fatalf("LoadFromFiles failed: %v", err)
+	}
+
+	if cfg.Host != "override.local" {
+		t.Errorf("Expected Host from last file, got %s", cfg.Host)
+	}
+	if cfg.Port != "8080" {
+		t.Errorf("Expected Port from first file, got %s", cfg.Port)
+	}
+
+	if err := Load(&cfg, WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if origin, _ := prov.Lookup("LAYER_HOST"); origin.String() != override+":1" {
+		t.Errorf("Expected origin %s:1, got %s", override, origin)
+	}
+}
+
+// TestLoad_SystemEnvWinsOverFiles testa que variáveis reais do sistema não são sobrescritas
+func TestLoad_SystemEnvWinsOverFiles(t *testing.T) {
+	t.Setenv("LAYER_HOST", "system.local")
+	path := writeEnvFile(t, "layer.env", "LAYER_HOST=file.local\nLAYER_PORT=9090\n", "LAYER_PORT")
+
+	var cfg LayerConfig
+	if err := Load(&cfg, WithFiles(path, path)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "system.local" {
+		t.Errorf("Expected Host from system, got %s", cfg.Host)
+	}
+	if cfg.Port != "9090" {
+		t.Errorf("Expected Port from file, got %s", cfg.Port)
+	}
+}
+
+// TestLoad_Profile testa as camadas .env, .env.<perfil>, .env.local e .env.<perfil>.local
+func TestLoad_Profile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".env":                  "LAYER_HOST=base\nLAYER_PORT=1\nLAYER_MODE=base\nLAYER_DEBUG=base\n",
+		".env.staging":          "LAYER_PORT=2\nLAYER_MODE=staging\nLAYER_DEBUG=staging\n",
+		".env.local":            "LAYER_MODE=local\nLAYER_DEBUG=local\n",
+		".env.staging.local":    "LAYER_DEBUG=staging-local\n",
+		".env.production":       "LAYER_HOST=production\n",
+		".env.production.local": "LAYER_HOST=production-local\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	for _, key := range layerKeys {
+		t.Cleanup(func() { os.Unsetenv(key) })
+	}
+
+	var trace bytes.Buffer
+	var cfg LayerConfig
+	if err := Load(&cfg, WithProfile("staging"), WithTrace(&trace)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	expected := LayerConfig{Host: "base", Port: "2", Mode: "local", Debug: "staging-local"}
+	if cfg != expected {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+	if !strings.Contains(trace.String(), "loaded from working directory: .env, .env.staging, .env.local, .env.staging.local") {
+		t.Errorf("Expected loaded files in trace, got:\n%s", trace.String())
+	}
+
+	// O perfil também pode vir de APP_ENV; valores de cargas anteriores são sobrescritos
+	t.Setenv("APP_ENV", "production")
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "production-local" || cfg.Port != "1" {
+		t.Errorf("Expected production layers, got %+v", cfg)
+	}
+}
+
+// TestLoad_ProfileWithEnvFiles testa camadas opcionais a partir de arquivos explícitos
+func TestLoad_ProfileWithEnvFiles(t *testing.T) {
+	base := writeEnvFile(t, "app.env", "LAYER_HOST=base\nLAYER_PORT=1\n", layerKeys...)
+	if err := os.WriteFile(base+".test", []byte("LAYER_PORT=2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg LayerConfig
+	if err := Load(&cfg, WithFiles(base), WithProfile("test")); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "base" || cfg.Port != "2" {
+		t.Errorf("Expected Host base and Port 2, got %+v", cfg)
+	}
+
+	if err := Load(&cfg, WithFiles(base+".missing"), WithProfile("test")); err == nil {
+		t.Error("Expected error for missing base file, got nil")
+	}
+}
@@ -0,0 +1,52 @@
+// Package configloadertest contém utilitários de teste para código que usa o configloader.
+package configloadertest
+
+import (
+	"reflect"
+	"testing"
+
+	configloader "github.com/wesleysantana/config-loader"
+)
+
+// AssertEquivalent verifica que load (normalmente gerada por configloader-gen) e o
+// loader por reflection (configloader.LoadFromSource com opts) produzem o mesmo valor
+// e o mesmo erro para src. Divergências são reportadas com t.Errorf. Para código
+// gerado com -validate, passe configloader.WithValidation() em opts.
+//
+// Exemplo:
+//
+//	func TestLoadConfigMatchesReflection(t *testing.T) {
+//	    configloadertest.AssertEquivalent(t, LoadConfig, configloader.MapSource{"PORT": "9090"})
+//	    configloadertest.AssertEquivalent(t, LoadConfig, configloader.MapSource{"PORT": "abc"})
+//	}
+func AssertEquivalent[T any](t testing.TB, load func(configloader.Source) (T, error), src configloader.Source, opts ...configloader.Option) {
+	t.Helper()
+
+	got, gotErr := load(src)
+
+	var want T
+	wantErr := configloader.LoadFromSource(&want, src, opts...)
+
+	if errorString(gotErr) != errorString(wantErr) {
+		t.Errorf("Expected error %q (reflection), got %q (generated)", errorString(wantErr), errorString(gotErr))
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		changes := configloader.Diff(want, got)
+		if len(changes) == 0 {
+			t.Errorf("Expected %+v (reflection), got %+v (generated)", want, got)
+			return
+		}
+		for _, change := range changes {
+			t.Errorf("Expected generated and reflective loaders to agree, got %s (reflection -> generated)", change)
+		}
+	}
+}
+
+// errorString retorna a mensagem de err, ou "" se err for nil.
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,90 @@
+package configloadertest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	configloader "github.com/wesleysantana/config-loader"
+)
+
+// recorder captura as falhas reportadas por AssertEquivalent.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type sampleConfig struct {
+	Port int    `env:"PORT,8080"`
+	Host string `env:"HOST,required"`
+}
+
+// loadSample é um loader escrito à mão equivalente ao gerado por configloader-gen.
+func loadSample(src configloader.Source) (sampleConfig, error) {
+	var cfg sampleConfig
+	r := configloader.NewResolver(src)
+
+	if raw, ok := r.Lookup("PORT", "8080"); ok {
+		v, err := configloader.ParseInt(raw)
+		if err != nil {
+			return cfg, r.FieldError("Port", err)
+		}
+		cfg.Port = int(v)
+	}
+
+	if raw, ok := r.Lookup("HOST", "required"); ok {
+		cfg.Host = raw
+	}
+
+	return cfg, r.Err()
+}
+
+// TestAssertEquivalentAgrees testa que loaders equivalentes não geram falhas
+func TestAssertEquivalentAgrees(t *testing.T) {
+	for _, src := range []configloader.MapSource{
+		{"HOST": "example.com"},
+		{"PORT": "9090", "HOST": "example.com"},
+		{"PORT": "abc"},
+		{},
+	} {
+		rec := &recorder{TB: t}
+		AssertEquivalent(rec, loadSample, src)
+		if len(rec.errors) > 0 {
+			t.Errorf("Expected no failures for %v, got %v", src, rec.errors)
+		}
+	}
+}
+
+// TestAssertEquivalentReportsDifferences testa que divergências de valor e de erro são reportadas
+func TestAssertEquivalentReportsDifferences(t *testing.T) {
+	wrongDefault := func(src configloader.Source) (sampleConfig, error) {
+		cfg, err := loadSample(src)
+		if _, ok := src.Lookup("PORT"); !ok {
+			cfg.Port = 80
+		}
+		return cfg, err
+	}
+
+	rec := &recorder{TB: t}
+	AssertEquivalent(rec, wrongDefault, configloader.MapSource{"HOST": "example.com"})
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "PORT: 8080 -> 80") {
+		t.Errorf("Expected value difference for PORT, got %v", rec.errors)
+	}
+
+	noValidation := func(src configloader.Source) (sampleConfig, error) {
+		cfg, _ := loadSample(src)
+		return cfg, nil
+	}
+
+	rec = &recorder{TB: t}
+	AssertEquivalent(rec, noValidation, configloader.MapSource{})
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "HOST is required") {
+		t.Errorf("Expected error difference, got %v", rec.errors)
+	}
+}
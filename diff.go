@@ -0,0 +1,80 @@
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Change descreve a alteração de uma variável de ambiente entre duas configurações.
+// Os valores já vêm formatados e mascarados com as mesmas regras de SPrint.
+type Change struct {
+	// Name é o nome da variável de ambiente (primeira parte da tag `env`).
+	Name string
+
+	// Field é o caminho do campo na struct, ex: "Port" ou "Database.Host".
+	Field string
+
+	// Old é o valor anterior formatado (mascarado para campos sensíveis).
+	Old string
+
+	// New é o novo valor formatado (mascarado para campos sensíveis).
+	New string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Name, c.Old, c.New)
+}
+
+// Diff compara duas configurações carregadas e retorna as variáveis que mudaram.
+// Apenas campos com tag `env` são comparados, na ordem em que aparecem na struct.
+// Campos sensíveis aparecem como alterados, mas com os valores mascarados.
+//
+// Parâmetros:
+//   - old: Configuração anterior (struct ou ponteiro para struct)
+//   - new: Nova configuração, do mesmo tipo de old
+//   - opts: Opções de mascaramento (opcional)
+//
+// Panics:
+//   - Se old e new não forem structs do mesmo tipo
+//
+// Exemplo:
+//
+//	previous := cfg
+//	if err := Reload(&cfg); err == nil {
+//	    for _, c := range Diff(previous, cfg) {
+//	        log.Printf("config changed: %s", c)
+//	    }
+//	}
+//
+// Retorna:
+//   - []Change: Lista de alterações (vazia se nada mudou)
+func Diff(old, new any, opts ...MaskOptions) []Change {
+	mask := resolveMaskOptions(opts)
+	oldValue := reflect.Indirect(reflect.ValueOf(old))
+	newValue := reflect.Indirect(reflect.ValueOf(new))
+	if oldValue.Kind() != reflect.Struct || oldValue.Type() != newValue.Type() {
+		panic(fmt.Sprintf("configloader: Diff requires two structs of the same type, got %T and %T", old, new))
+	}
+
+	var changes []Change
+	for _, fi := range structFields(oldValue.Type()) {
+		oldField := oldValue.FieldByIndex(fi.index)
+		newField := newValue.FieldByIndex(fi.index)
+		if !oldField.CanInterface() {
+			continue
+		}
+
+		if reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Name:  fi.name,
+			Field: fi.path(),
+			Old:   mask.displayValue(fi, oldField),
+			New:   mask.displayValue(fi, newField),
+		})
+	}
+
+	return changes
+}
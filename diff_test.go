@@ -0,0 +1,51 @@
+package configloader
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDiff testa a comparação entre duas configurações
+func TestDiff(t *testing.T) {
+	old := TestConfig{
+		ServerPort: "8080",
+		DBPassword: "old_secret",
+		Timeout:    30 * time.Second,
+	}
+	updated := old
+	updated.ServerPort = "9090"
+	updated.DBPassword = "new_secret"
+
+	changes := Diff(old, &updated)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %v", len(changes), changes)
+	}
+
+	if changes[0].Name != "SERVER_PORT" || changes[0].Old != "8080" || changes[0].New != "9090" {
+		t.Errorf("Unexpected change for SERVER_PORT: %+v", changes[0])
+	}
+
+	// Campos sensíveis são reportados com valores mascarados
+	if changes[1].Name != "DB_PASSWORD" || changes[1].Old != maskedValue || changes[1].New != maskedValue {
+		t.Errorf("Expected masked change for DB_PASSWORD, got %+v", changes[1])
+	}
+}
+
+// TestDiff_NoChanges testa configurações iguais
+func TestDiff_NoChanges(t *testing.T) {
+	cfg := TestConfig{AllowedHosts: []string{"localhost"}}
+	if changes := Diff(cfg, cfg); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
+
+// TestDiff_DifferentTypes testa panic com tipos diferentes
+func TestDiff_DifferentTypes(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for different types, but none occurred")
+		}
+	}()
+
+	Diff(TestConfig{}, ReloadConfig{})
+}
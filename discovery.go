@@ -0,0 +1,139 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DiscoveryOptions configura a busca de arquivos .env feita por DiscoverEnvFiles
+// e, com WithDiscovery, por Load e FindAndLoad.
+//
+// A partir de cada diretório em Roots, a busca procura os arquivos em Names e sobe
+// pelos diretórios pais até chegar a um diretório que contenha um dos Markers
+// (a raiz do projeto, inclusive) ou à raiz do sistema de arquivos.
+type DiscoveryOptions struct {
+	// Names são os nomes de arquivo procurados em cada diretório, em ordem de
+	// precedência crescente. Padrão: [".env"].
+	Names []string
+
+	// Roots são os diretórios onde a busca começa. Padrão: diretório atual.
+	Roots []string
+
+	// Markers são arquivos ou diretórios que identificam a raiz do projeto.
+	// Padrão: ["go.mod", ".git"].
+	Markers []string
+
+	// All carrega todos os arquivos encontrados, em camadas: arquivos de diretórios
+	// mais próximos de Roots têm precedência sobre os de diretórios acima.
+	// Se false, apenas um arquivo é carregado: no diretório mais próximo com algum
+	// dos Names, o de maior precedência (o último de Names).
+	All bool
+}
+
+// withDefaults preenche os campos vazios com os padrões.
+func (d DiscoveryOptions) withDefaults() DiscoveryOptions {
+	if len(d.Names) == 0 {
+		d.Names = []string{".env"}
+	}
+	if len(d.Roots) == 0 {
+		d.Roots = []string{"."}
+	}
+	if d.Markers == nil {
+		d.Markers = []string{"go.mod", ".git"}
+	}
+	return d
+}
+
+// DiscoverEnvFiles procura arquivos .env conforme opts e retorna os caminhos na
+// ordem em que devem ser carregados (o último tem precedência). Se opts.All for
+// false, retorna no máximo um arquivo: o de maior precedência do diretório mais próximo.
+//
+// Parâmetros:
+//   - opts: Nomes, diretórios iniciais e marcadores da raiz do projeto
+//
+// Exemplo:
+//
+//	// De ./services/api, encontra services/api/.env e .env na raiz (onde está o go.mod)
+//	files, err := DiscoverEnvFiles(DiscoveryOptions{All: true})
+//	// [/repo/.env /repo/services/api/.env]
+//
+// Retorna:
+//   - []string: Caminhos encontrados, do menos para o mais prioritário
+//   - error: Erro se um diretório inicial não puder ser resolvido
+func DiscoverEnvFiles(opts DiscoveryOptions) ([]string, error) {
+	return discoverEnvFiles(opts, func(string, ...any) {})
+}
+
+// discoverEnvFiles implementa DiscoverEnvFiles, registrando cada caminho verificado em tracef.
+func discoverEnvFiles(opts DiscoveryOptions, tracef func(format string, args ...any)) ([]string, error) {
+	opts = opts.withDefaults()
+
+	var found []string
+	seen := make(map[string]bool)
+	for _, root := range opts.Roots {
+		dir, err := filepath.Abs(root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discovery root %s: %w", root, err)
+		}
+
+		// Arquivos deste root, do diretório mais próximo para o mais distante.
+		var nearest [][]string
+		for {
+			var inDir []string
+			for _, name := range opts.Names {
+				path := filepath.Join(dir, name)
+				if info, err := os.Stat(path); err == nil && !info.IsDir() {
+					tracef("trying %s: found", path)
+					inDir = append(inDir, path)
+				} else {
+					tracef("trying %s: not found", path)
+				}
+			}
+			if len(inDir) > 0 {
+				nearest = append(nearest, inDir)
+				if !opts.All {
+					break
+				}
+			}
+
+			if hasMarker(dir, opts.Markers) {
+				tracef("stopping at %s: project root", dir)
+				break
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+
+		if !opts.All {
+			if len(nearest) > 0 {
+				return nearest[0][len(nearest[0])-1:], nil
+			}
+			continue
+		}
+
+		for i := len(nearest) - 1; i >= 0; i-- {
+			for _, path := range nearest[i] {
+				if !seen[path] {
+					seen[path] = true
+					found = append(found, path)
+				}
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// hasMarker indica se dir contém algum dos marcadores.
+func hasMarker(dir string, markers []string) bool {
+	for _, marker := range markers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// DiscoveryConfig struct para testes de descoberta de arquivos .env
+type DiscoveryConfig struct {
+	Host string `env:"DISC_HOST"`
+	Port string `env:"DISC_PORT"`
+}
+
+// discoveryTree cria a árvore:
+//
+//	outside/.env            (fora do projeto, não deve ser encontrado)
+//	outside/repo/go.mod
+//	outside/repo/.env
+//	outside/repo/services/api/.env
+//	outside/repo/services/api/.env.local
+//
+// e retorna o diretório repo, com o diretório atual em services/api.
+func discoveryTree(t *testing.T) string {
+	t.Helper()
+
+	outside := t.TempDir()
+	repo := filepath.Join(outside, "repo")
+	api := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(api, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(outside, ".env"):   "DISC_HOST=outside\n",
+		filepath.Join(repo, "go.mod"):    "module example.com/repo\n",
+		filepath.Join(repo, ".env"):      "DISC_HOST=repo\nDISC_PORT=8080\n",
+		filepath.Join(api, ".env"):       "DISC_HOST=api\n",
+		filepath.Join(api, ".env.local"): "DISC_PORT=9090\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(api)
+	t.Cleanup(func() {
+		os.Unsetenv("DISC_HOST")
+		os.Unsetenv("DISC_PORT")
+	})
+	return repo
+}
+
+// TestDiscoverEnvFiles testa a busca subindo até a raiz do projeto
+func TestDiscoverEnvFiles(t *testing.T) {
+	repo := discoveryTree(t)
+	api := filepath.Join(repo, "services", "api")
+
+	tests := []struct {
+		name     string
+		opts     DiscoveryOptions
+		expected []string
+	}{
+		{"nearest", DiscoveryOptions{}, []string{filepath.Join(api, ".env")}},
+		{"nearest by precedence", DiscoveryOptions{Names: []string{".env", ".env.local"}}, []string{filepath.Join(api, ".env.local")}},
+		{"nearest missing name", DiscoveryOptions{Names: []string{".env", ".env.prod"}}, []string{filepath.Join(api, ".env")}},
+		{"all", DiscoveryOptions{All: true}, []string{filepath.Join(repo, ".env"), filepath.Join(api, ".env")}},
+		{"names", DiscoveryOptions{Names: []string{".env", ".env.local"}, All: true}, []string{
+			filepath.Join(repo, ".env"), filepath.Join(api, ".env"), filepath.Join(api, ".env.local"),
+		}},
+		{"roots", DiscoveryOptions{Roots: []string{repo}, All: true}, []string{filepath.Join(repo, ".env")}},
+		{"marker", DiscoveryOptions{Markers: []string{"api"}, All: true}, []string{filepath.Join(api, ".env")}},
+		{"missing", DiscoveryOptions{Names: []string{"app.env"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := DiscoverEnvFiles(tt.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(found, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, found)
+			}
+		})
+	}
+}
+
+// TestFindAndLoad_Discovery testa o carregamento em camadas dos arquivos encontrados
+func TestFindAndLoad_Discovery(t *testing.T) {
+	repo := discoveryTree(t)
+
+	var used []string
+	var cfg DiscoveryConfig
+	err := FindAndLoad(&cfg, WithDiscovery(DiscoveryOptions{Names: []string{".env", ".env.local"}, All: true}), WithLoadedFiles(&used))
+	if err != nil {
+		t.Fatalf("FindAndLoad failed: %v", err)
+	}
+
+	if cfg.Host != "api" || cfg.Port != "9090" {
+		t.Errorf("Expected nearest files to win, got %+v", cfg)
+	}
+	if len(used) != 3 || used[0] != filepath.Join(repo, ".env") {
+		t.Errorf("Expected 3 loaded files starting at repo root, got %v", used)
+	}
+}
+
+// TestLoad_DiscoveryWithProfile testa a descoberta combinada com perfis em Load
+func TestLoad_DiscoveryWithProfile(t *testing.T) {
+	repo := discoveryTree(t)
+	if err := os.WriteFile(filepath.Join(repo, ".env.prod"), []byte("DISC_PORT=443\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var used []string
+	var cfg DiscoveryConfig
+	err := Load(&cfg, WithDiscovery(DiscoveryOptions{Roots: []string{repo}}), WithProfile("prod"), WithLoadedFiles(&used))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Host != "repo" || cfg.Port != "443" {
+		t.Errorf("Expected repo values with prod profile, got %+v", cfg)
+	}
+	expected := []string{filepath.Join(repo, ".env"), filepath.Join(repo, ".env.prod")}
+	if !reflect.DeepEqual(used, expected) {
+		t.Errorf("Expected loaded files %v, got %v", expected, used)
+	}
+}
+
+// TestFindAndLoad_LoadedFiles testa o relato do arquivo usado pela busca padrão
+func TestFindAndLoad_LoadedFiles(t *testing.T) {
+	discoveryTree(t)
+
+	var used []string
+	var cfg DiscoveryConfig
+	if err := FindAndLoad(&cfg, WithLoadedFiles(&used)); err != nil {
+		t.Fatalf("FindAndLoad failed: %v", err)
+	}
+	if !reflect.DeepEqual(used, []string{".env"}) {
+		t.Errorf("Expected [.env], got %v", used)
+	}
+}
+
+// TestFindAndLoad_Profile testa o perfil na busca padrão, sem WithDiscovery
+func TestFindAndLoad_Profile(t *testing.T) {
+	discoveryTree(t)
+	if err := os.WriteFile(".env.prod", []byte("DISC_HOST=api-prod\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var used []string
+	var cfg DiscoveryConfig
+	if err := FindAndLoad(&cfg, WithProfile("prod"), WithLoadedFiles(&used)); err != nil {
+		t.Fatalf("FindAndLoad failed: %v", err)
+	}
+	if cfg.Host != "api-prod" || cfg.Port != "9090" {
+		t.Errorf("Expected values from .env.prod and .env.local, got %+v", cfg)
+	}
+	if expected := []string{".env", ".env.prod", ".env.local"}; !reflect.DeepEqual(used, expected) {
+		t.Errorf("Expected %v, got %v", expected, used)
+	}
+}
@@ -0,0 +1,320 @@
+package configloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Format identifica o formato de saída de Dump.
+type Format string
+
+const (
+	// FormatText é a tabela de texto de SPrint.
+	FormatText Format = "text"
+
+	// FormatJSON é um objeto JSON indentado; structs aninhadas viram objetos aninhados.
+	FormatJSON Format = "json"
+
+	// FormatYAML é um documento YAML; structs aninhadas viram mapas aninhados.
+	FormatYAML Format = "yaml"
+
+	// FormatDotenv é um arquivo .env (NOME=valor), com um comentário por struct aninhada.
+	FormatDotenv Format = "dotenv"
+
+	// FormatMarkdown é uma tabela Markdown com variável, campo e valor.
+	FormatMarkdown Format = "markdown"
+)
+
+// Dump serializa as configurações carregadas no formato informado.
+// Todos os formatos aplicam o mesmo mascaramento de SPrint (campos sensíveis,
+// Secret[T] e credenciais em URLs). Nos formatos estruturados (JSON e YAML),
+// as chaves são os nomes das variáveis de ambiente, agrupadas pelo nome dos
+// campos de structs aninhadas, e valores não sensíveis mantêm o tipo
+// (números, booleanos, listas).
+//
+// Parâmetros:
+//   - config: Struct (ou ponteiro) com as configurações carregadas
+//   - format: Formato de saída (FormatJSON, FormatYAML, FormatDotenv, FormatMarkdown, FormatText)
+//   - opts: Opções de mascaramento (opcional)
+//
+// Exemplo:
+//
+//	out, err := Dump(cfg, FormatJSON)
+//	// {
+//	//   "SERVER_PORT": "8080",
+//	//   "DB_PASSWORD": "***MASKED***",
+//	//   "Database": {
+//	//     "DB_HOST": "localhost"
+//	//   }
+//	// }
+//
+// Retorna:
+//   - string: Configurações serializadas
+//   - error: Erro se config não for uma struct ou o formato for desconhecido
+func Dump(config any, format Format, opts ...MaskOptions) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(config))
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("config must be a struct or a pointer to a struct")
+	}
+
+	mask := resolveMaskOptions(opts)
+	entries := dumpEntries(v, mask)
+
+	switch format {
+	case FormatText:
+		return SPrint(config, mask), nil
+	case FormatJSON:
+		var b strings.Builder
+		if err := writeJSONNode(&b, buildDumpTree(entries), 0); err != nil {
+			return "", err
+		}
+		b.WriteString("\n")
+		return b.String(), nil
+	case FormatYAML:
+		var b strings.Builder
+		writeYAMLNode(&b, buildDumpTree(entries), 0)
+		return b.String(), nil
+	case FormatDotenv:
+		return dumpDotenv(entries), nil
+	case FormatMarkdown:
+		return dumpMarkdown(entries), nil
+	default:
+		return "", fmt.Errorf("unsupported dump format: %s", format)
+	}
+}
+
+// DumpAttr retorna as configurações como um grupo slog, com o mesmo mascaramento de Dump.
+// Structs aninhadas viram subgrupos.
+//
+// Exemplo:
+//
+//	logger.Info("effective config", DumpAttr("config", cfg))
+//	// msg="effective config" config.SERVER_PORT=8080 config.DB_PASSWORD=***MASKED*** config.Database.DB_HOST=localhost
+func DumpAttr(key string, config any, opts ...MaskOptions) slog.Attr {
+	v := reflect.Indirect(reflect.ValueOf(config))
+	if v.Kind() != reflect.Struct {
+		return slog.String(key, fmt.Sprintf("%v", config))
+	}
+
+	root := buildDumpTree(dumpEntries(v, resolveMaskOptions(opts)))
+	return slogNode(key, root)
+}
+
+// dumpEntry é um campo pronto para serialização.
+type dumpEntry struct {
+	fi    fieldInfo
+	value any
+}
+
+// dumpEntries extrai os valores de todos os campos com tag `env`, já mascarados.
+func dumpEntries(v reflect.Value, mask MaskOptions) []dumpEntry {
+	var entries []dumpEntry
+	for _, fi := range structFields(v.Type()) {
+		field := v.FieldByIndex(fi.index)
+		if !field.CanInterface() {
+			continue
+		}
+		entries = append(entries, dumpEntry{fi: fi, value: dumpValue(fi, field, mask)})
+	}
+	return entries
+}
+
+// dumpValue converte o valor de um campo para serialização: campos sensíveis viram
+// a string mascarada, números e booleanos mantêm o tipo e os demais viram texto.
+func dumpValue(fi fieldInfo, field reflect.Value, mask MaskOptions) any {
+	if mask.isSensitiveField(fi) || field.Type() == durationType {
+		return mask.displayValue(fi, field)
+	}
+
+	switch field.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return field.Interface()
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String {
+			items := make([]string, field.Len())
+			for i := range items {
+				items[i] = redactURL(field.Index(i).String())
+			}
+			return items
+		}
+	}
+
+	return mask.displayValue(fi, field)
+}
+
+// dumpString formata um valor de dumpValue como texto (listas separadas por vírgula).
+func dumpString(value any) string {
+	if items, ok := value.([]string); ok {
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// dumpNode é um nó da árvore de serialização: um grupo (struct aninhada) ou uma folha.
+type dumpNode struct {
+	key      string
+	value    any
+	children []*dumpNode
+}
+
+// group retorna o subgrupo com a chave informada, criando-o se necessário.
+func (n *dumpNode) group(key string) *dumpNode {
+	for _, child := range n.children {
+		if child.key == key && child.children != nil {
+			return child
+		}
+	}
+	child := &dumpNode{key: key, children: []*dumpNode{}}
+	n.children = append(n.children, child)
+	return child
+}
+
+// buildDumpTree organiza os campos em grupos pelo caminho de structs aninhadas.
+func buildDumpTree(entries []dumpEntry) *dumpNode {
+	root := &dumpNode{children: []*dumpNode{}}
+	for _, entry := range entries {
+		node := root
+		for _, group := range entry.fi.groups {
+			node = node.group(group)
+		}
+		node.children = append(node.children, &dumpNode{key: entry.fi.name, value: entry.value})
+	}
+	return root
+}
+
+// writeJSONNode escreve um grupo como objeto JSON indentado, preservando a ordem dos campos.
+func writeJSONNode(b *strings.Builder, node *dumpNode, depth int) error {
+	if len(node.children) == 0 {
+		b.WriteString("{}")
+		return nil
+	}
+
+	indent := strings.Repeat("  ", depth+1)
+	b.WriteString("{\n")
+	for i, child := range node.children {
+		b.WriteString(indent)
+		b.WriteString(strconv.Quote(child.key))
+		b.WriteString(": ")
+
+		if child.children != nil {
+			if err := writeJSONNode(b, child, depth+1); err != nil {
+				return err
+			}
+		} else {
+			data, err := json.Marshal(child.value)
+			if err != nil {
+				return fmt.Errorf("error encoding %s: %w", child.key, err)
+			}
+			b.Write(data)
+		}
+
+		if i < len(node.children)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString("}")
+	return nil
+}
+
+// writeYAMLNode escreve um grupo como mapa YAML. Strings são sempre entre aspas duplas.
+func writeYAMLNode(b *strings.Builder, node *dumpNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, child := range node.children {
+		if child.children != nil {
+			fmt.Fprintf(b, "%s%s:\n", indent, child.key)
+			writeYAMLNode(b, child, depth+1)
+			continue
+		}
+
+		switch value := child.value.(type) {
+		case []string:
+			if len(value) == 0 {
+				fmt.Fprintf(b, "%s%s: []\n", indent, child.key)
+				continue
+			}
+			fmt.Fprintf(b, "%s%s:\n", indent, child.key)
+			for _, item := range value {
+				fmt.Fprintf(b, "%s  - %s\n", indent, strconv.Quote(item))
+			}
+		case string:
+			fmt.Fprintf(b, "%s%s: %s\n", indent, child.key, strconv.Quote(value))
+		default:
+			fmt.Fprintf(b, "%s%s: %v\n", indent, child.key, value)
+		}
+	}
+}
+
+// dumpDotenv escreve os campos no formato .env, com um comentário ao entrar em cada grupo.
+// Os campos de primeiro nível vêm antes de todos os grupos, como em Usage.
+func dumpDotenv(entries []dumpEntry) string {
+	var b strings.Builder
+	currentGroup := ""
+	for _, entry := range bySection(entries, func(e dumpEntry) fieldInfo { return e.fi }) {
+		if group := strings.Join(entry.fi.groups, "."); group != currentGroup {
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			if group != "" {
+				fmt.Fprintf(&b, "# %s\n", group)
+			}
+			currentGroup = group
+		}
+		fmt.Fprintf(&b, "%s=%s\n", entry.fi.name, quoteDotenv(dumpString(entry.value)))
+	}
+	return b.String()
+}
+
+// quoteDotenv coloca um valor entre aspas quando necessário para ser lido pelo godotenv.
+// Aspas simples são preferidas porque o godotenv não expande nada dentro delas.
+func quoteDotenv(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n#\"'`$\\=") {
+		return value
+	}
+	if !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// dumpMarkdown escreve os campos como tabela Markdown.
+func dumpMarkdown(entries []dumpEntry) string {
+	var b strings.Builder
+	b.WriteString("| Variable | Field | Value |\n")
+	b.WriteString("|----------|-------|-------|\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", entry.fi.name, entry.fi.path(), markdownCode(dumpString(entry.value)))
+	}
+	return b.String()
+}
+
+// markdownCode formata um valor como código inline dentro de uma célula de tabela Markdown.
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(value, "|", `\|`) + "`"
+}
+
+// slogNode converte um grupo em slog.Attr, com subgrupos para structs aninhadas.
+func slogNode(key string, node *dumpNode) slog.Attr {
+	attrs := make([]any, 0, len(node.children))
+	for _, child := range node.children {
+		if child.children != nil {
+			attrs = append(attrs, slogNode(child.key, child))
+		} else {
+			attrs = append(attrs, slog.Any(child.key, child.value))
+		}
+	}
+	return slog.Group(key, attrs...)
+}
@@ -0,0 +1,172 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// DumpConfig struct com structs aninhadas para testes de Dump
+type DumpConfig struct {
+	Port     string `env:"DUMP_PORT,8080"`
+	Debug    bool   `env:"DUMP_DEBUG,true"`
+	Database struct {
+		Host     string        `env:"DUMP_DB_HOST,localhost"`
+		Password string        `env:"DUMP_DB_PASSWORD,hunter2"`
+		Timeout  time.Duration `env:"DUMP_DB_TIMEOUT,5s"`
+	}
+	Hosts []string `env:"DUMP_HOSTS,a,b"`
+}
+
+// loadDumpConfig carrega DumpConfig apenas com os defaults
+func loadDumpConfig(t *testing.T) DumpConfig {
+	t.Helper()
+
+	var cfg DumpConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return cfg
+}
+
+// TestLoad_NestedStruct testa o carregamento de structs aninhadas
+func TestLoad_NestedStruct(t *testing.T) {
+	os.Setenv("DUMP_DB_HOST", "db.internal")
+	defer os.Unsetenv("DUMP_DB_HOST")
+
+	cfg := loadDumpConfig(t)
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("Expected Database.Host db.internal, got %s", cfg.Database.Host)
+	}
+	if cfg.Database.Timeout != 5*time.Second {
+		t.Errorf("Expected Database.Timeout 5s, got %v", cfg.Database.Timeout)
+	}
+}
+
+// TestDump_JSON testa a saída JSON
+func TestDump_JSON(t *testing.T) {
+	out, err := Dump(loadDumpConfig(t), FormatJSON)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	expected := `{
+  "DUMP_PORT": "8080",
+  "DUMP_DEBUG": true,
+  "Database": {
+    "DUMP_DB_HOST": "localhost",
+    "DUMP_DB_PASSWORD": "***MASKED***",
+    "DUMP_DB_TIMEOUT": "5s"
+  },
+  "DUMP_HOSTS": ["a","b"]
+}
+`
+	if out != expected {
+		t.Errorf("Unexpected JSON output:\n%s", out)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Errorf("Output is not valid JSON: %v", err)
+	}
+}
+
+// TestDump_YAML testa a saída YAML
+func TestDump_YAML(t *testing.T) {
+	out, err := Dump(loadDumpConfig(t), FormatYAML)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	expected := `DUMP_PORT: "8080"
+DUMP_DEBUG: true
+Database:
+  DUMP_DB_HOST: "localhost"
+  DUMP_DB_PASSWORD: "***MASKED***"
+  DUMP_DB_TIMEOUT: "5s"
+DUMP_HOSTS:
+  - "a"
+  - "b"
+`
+	if out != expected {
+		t.Errorf("Unexpected YAML output:\n%s", out)
+	}
+}
+
+// TestDump_Dotenv testa a saída .env
+func TestDump_Dotenv(t *testing.T) {
+	out, err := Dump(loadDumpConfig(t), FormatDotenv)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	// DUMP_HOSTS é declarado depois de Database, mas fica com os campos de primeiro nível
+	expected := `DUMP_PORT=8080
+DUMP_DEBUG=true
+DUMP_HOSTS=a,b
+
+# Database
+DUMP_DB_HOST=localhost
+DUMP_DB_PASSWORD=***MASKED***
+DUMP_DB_TIMEOUT=5s
+`
+	if out != expected {
+		t.Errorf("Unexpected dotenv output:\n%s", out)
+	}
+}
+
+// TestDump_Markdown testa a saída Markdown
+func TestDump_Markdown(t *testing.T) {
+	out, err := Dump(loadDumpConfig(t), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	if !strings.Contains(out, "| `DUMP_DB_HOST` | Database.Host | `localhost` |") {
+		t.Errorf("Expected nested field row, got:\n%s", out)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("Sensitive value leaked in output:\n%s", out)
+	}
+}
+
+// TestDump_UnknownFormat testa erro com formato desconhecido
+func TestDump_UnknownFormat(t *testing.T) {
+	if _, err := Dump(DumpConfig{}, Format("xml")); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+}
+
+// TestDumpAttr testa o grupo slog
+func TestDumpAttr(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("effective config", DumpAttr("config", loadDumpConfig(t)))
+
+	output := buf.String()
+	if !strings.Contains(output, "config.DUMP_PORT=8080 config.DUMP_DEBUG=true config.Database.DUMP_DB_HOST=localhost config.Database.DUMP_DB_PASSWORD=***MASKED***") {
+		t.Errorf("Unexpected slog output:\n%s", output)
+	}
+}
+
+// TestQuoteDotenv testa as aspas de valores .env
+func TestQuoteDotenv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"plain", "plain"},
+		{"", "''"},
+		{"with space", "'with space'"},
+		{"it's $HOME", `"it's \$HOME"`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteDotenv(tt.value); got != tt.expected {
+			t.Errorf("quoteDotenv(%q): expected %s, got %s", tt.value, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package configloader
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+	urlPtrType   = reflect.TypeOf(&url.URL{})
+)
+
+// fieldInfo descreve um campo com tag `env`, possivelmente dentro de structs aninhadas.
+type fieldInfo struct {
+	// field é o campo da struct onde a tag está declarada.
+	field reflect.StructField
+
+	// index é o caminho de índices a partir da struct raiz (para FieldByIndex).
+	index []int
+
+	// groups são os nomes dos campos struct aninhados que contêm o campo,
+	// da raiz até o pai direto. Vazio para campos de primeiro nível.
+	groups []string
+
+	// parents são os campos struct aninhados correspondentes a groups, usados para
+	// montar caminhos de chave em arquivos de configuração (ver keyPath).
+	parents []reflect.StructField
+
+	// name é o nome da variável de ambiente.
+	name string
+
+	// tag são as partes da tag `env`, como retornadas por parseEnvTag.
+	tag []string
+
+	// fieldPath é o caminho do campo a partir da struct raiz, ex: "Database.Host".
+	fieldPath string
+
+	// words são as palavras do nome do campo e da variável, usadas para
+	// detectar campos sensíveis por palavra-chave (ver splitWords).
+	words []string
+
+	// sensitive indica se o campo é sensível com as MaskOptions padrão; é calculado
+	// uma vez por tipo para que Load não repita a detecção a cada carga.
+	sensitive bool
+}
+
+// path retorna o caminho do campo a partir da struct raiz, ex: "Database.Host".
+func (fi fieldInfo) path() string {
+	return fi.fieldPath
+}
+
+// keyPath retorna o caminho da chave do campo em um arquivo de configuração do formato
+// informado (a tag com o nome do formato, ex: `yaml:"host"`), com os pais separados por
+// pontos como em yaml.v3: cada struct aninhada contribui com o nome da sua tag ou o
+// nome do campo em minúsculas, ex: "database.host". Retorna false se o campo não
+// tiver a tag.
+func (fi fieldInfo) keyPath(format string) (string, bool) {
+	name, ok := tagKey(fi.field, format)
+	if !ok {
+		return "", false
+	}
+
+	segments := make([]string, 0, len(fi.parents)+1)
+	for _, parent := range fi.parents {
+		segment, ok := tagKey(parent, format)
+		if !ok {
+			segment = strings.ToLower(parent.Name)
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(append(segments, name), "."), true
+}
+
+// tagKey retorna o nome da chave na tag format do campo, sem opções como ",omitempty".
+func tagKey(field reflect.StructField, format string) (string, bool) {
+	tag, ok := field.Tag.Lookup(format)
+	name, _, _ := strings.Cut(tag, ",")
+	if !ok || name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+// required indica se o campo foi marcado como required na tag `env`.
+func (fi fieldInfo) required() bool {
+	return len(fi.tag) > 1 && fi.tag[1] == "required"
+}
+
+// defaultValue retorna o valor default da tag `env`, se houver.
+func (fi fieldInfo) defaultValue() (string, bool) {
+	if len(fi.tag) > 1 && fi.tag[1] != "required" {
+		return fi.tag[1], true
+	}
+	return "", false
+}
+
+// deprecatedNames retorna os nomes antigos da variável (tag `deprecated`, separados por
+// vírgula), consultados no ambiente quando o nome atual não está definido.
+func (fi fieldInfo) deprecatedNames() []string {
+	tag := fi.field.Tag.Get("deprecated")
+	if tag == "" {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(tag, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// structFields retorna os campos com tag `env` de uma struct, na ordem de declaração.
+// Campos struct exportados sem tag `env` (exceto url.URL e Secret) são percorridos
+// recursivamente, de modo que configurações podem ser agrupadas em structs aninhadas:
+//
+//	type Config struct {
+//	    Port     string `env:"PORT,8080"`
+//	    Database struct {
+//	        Host string `env:"DB_HOST,localhost"`
+//	    }
+//	}
+//
+// Structs embutidas (anônimas) têm seus campos promovidos, sem criar um grupo.
+// A tag `env:"-"` ignora o campo, inclusive uma struct aninhada inteira.
+//
+// O resultado é calculado uma vez por tipo e guardado em fieldCache; não deve ser modificado.
+func structFields(t reflect.Type) []fieldInfo {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]fieldInfo)
+	}
+
+	var fields []fieldInfo
+	collectFields(t, nil, nil, nil, &fields)
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.([]fieldInfo)
+}
+
+// fieldCache guarda os campos de cada tipo de struct já percorrido, para que cargas
+// repetidas (Reload, configurações por requisição) não refaçam a reflection nem
+// o parse das tags.
+var fieldCache sync.Map // map[reflect.Type][]fieldInfo
+
+// collectFields percorre t acumulando os campos com tag `env` em fields.
+func collectFields(t reflect.Type, index []int, groups []string, parents []reflect.StructField, fields *[]fieldInfo) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		envTag := field.Tag.Get("env")
+		if envTag == "-" {
+			continue
+		}
+		if envTag != "" {
+			parts := parseEnvTag(envTag)
+			words := fieldWords(field)
+			*fields = append(*fields, fieldInfo{
+				field:     field,
+				index:     fieldIndex,
+				groups:    groups,
+				parents:   parents,
+				name:      parts[0],
+				tag:       parts,
+				fieldPath: strings.Join(append(append([]string{}, groups...), field.Name), "."),
+				words:     words,
+				sensitive: MaskOptions{}.detectSensitive(field, words),
+			})
+			continue
+		}
+
+		if !isNestedStruct(field) {
+			continue
+		}
+
+		if field.Anonymous {
+			collectFields(field.Type, fieldIndex, groups, parents, fields)
+		} else {
+			collectFields(field.Type, fieldIndex, append(append([]string{}, groups...), field.Name),
+				append(append([]reflect.StructField{}, parents...), field), fields)
+		}
+	}
+}
+
+// isNestedStruct indica se um campo sem tag `env` deve ser percorrido como grupo.
+func isNestedStruct(field reflect.StructField) bool {
+	if !field.IsExported() || field.Type.Kind() != reflect.Struct {
+		return false
+	}
+	return field.Type != urlType && !isSecretType(field.Type)
+}
@@ -0,0 +1,182 @@
+package configloader
+
+import (
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// BenchConfig struct de tamanho médio usada nos benchmarks
+type BenchConfig struct {
+	AppName  string        `env:"BENCH_APP_NAME,demo"`
+	Port     int           `env:"BENCH_PORT,8080" min:"1" max:"65535"`
+	Debug    bool          `env:"BENCH_DEBUG,false"`
+	LogLevel string        `env:"BENCH_LOG_LEVEL,info" enum:"debug,info,warn,error"`
+	Timeout  time.Duration `env:"BENCH_TIMEOUT,30s"`
+	Hosts    []string      `env:"BENCH_HOSTS,a.local,b.local"`
+	Ratio    float64       `env:"BENCH_RATIO,0.5"`
+	APIKey   SecretString  `env:"BENCH_API_KEY,required"`
+
+	Database struct {
+		Host     string        `env:"BENCH_DB_HOST,localhost"`
+		Port     int           `env:"BENCH_DB_PORT,5432"`
+		User     string        `env:"BENCH_DB_USER,app"`
+		Password string        `env:"BENCH_DB_PASSWORD,required"`
+		MaxConns int           `env:"BENCH_DB_MAX_CONNS,10"`
+		Timeout  time.Duration `env:"BENCH_DB_TIMEOUT,5s"`
+	}
+
+	Cache struct {
+		URL string        `env:"BENCH_CACHE_URL,redis://localhost:6379"`
+		TTL time.Duration `env:"BENCH_CACHE_TTL,1m"`
+	}
+}
+
+// benchSource define apenas os campos obrigatórios de BenchConfig
+var benchSource = MapSource{
+	"BENCH_API_KEY":     "abcd1234",
+	"BENCH_DB_PASSWORD": "s3cret",
+	"BENCH_PORT":        "9090",
+}
+
+// TestStructFieldsCached testa que os campos de um tipo são calculados uma vez e reutilizados
+func TestStructFieldsCached(t *testing.T) {
+	typ := reflect.TypeOf(BenchConfig{})
+	first := structFields(typ)
+	second := structFields(typ)
+
+	if len(first) != 16 {
+		t.Fatalf("Expected 16 fields, got %d", len(first))
+	}
+	if &first[0] != &second[0] {
+		t.Errorf("Expected cached fields to be reused")
+	}
+	if first[8].path() != "Database.Host" {
+		t.Errorf("Expected path 'Database.Host', got '%s'", first[8].path())
+	}
+}
+
+// TestStructFieldsConcurrent testa o cache com cargas concorrentes do mesmo tipo
+func TestStructFieldsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var cfg BenchConfig
+			errs <- LoadFromSource(&cfg, benchSource)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	}
+}
+
+// BenchmarkStructFields mede a obtenção dos campos de um tipo já analisado
+func BenchmarkStructFields(b *testing.B) {
+	typ := reflect.TypeOf(BenchConfig{})
+	b.ReportAllocs()
+	for b.Loop() {
+		structFields(typ)
+	}
+}
+
+// BenchmarkLoadFromSource mede uma carga completa a partir de uma Source em memória
+func BenchmarkLoadFromSource(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		var cfg BenchConfig
+		if err := LoadFromSource(&cfg, benchSource); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoadFromEnv mede uma carga completa a partir de variáveis de ambiente
+func BenchmarkLoadFromEnv(b *testing.B) {
+	for key, value := range benchSource {
+		b.Setenv(key, value)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var cfg BenchConfig
+		if err := LoadFromEnv(&cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSPrint mede a formatação de uma configuração carregada
+func BenchmarkSPrint(b *testing.B) {
+	var cfg BenchConfig
+	if err := LoadFromSource(&cfg, benchSource); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		SPrint(cfg)
+	}
+}
+
+// BenchmarkDiff mede a comparação de duas configurações
+func BenchmarkDiff(b *testing.B) {
+	var old, updated BenchConfig
+	if err := LoadFromSource(&old, benchSource); err != nil {
+		b.Fatal(err)
+	}
+	updated = old
+	updated.Port = 9091
+
+	b.ReportAllocs()
+	for b.Loop() {
+		Diff(old, updated)
+	}
+}
+
+// TestStructFieldsNested fixa quais campos struct sem tag `env` são percorridos:
+// structs aninhadas e embutidas exportadas sim; campos não exportados, url.URL,
+// Secret e campos com `env:"-"` não
+func TestStructFieldsNested(t *testing.T) {
+	type Embedded struct {
+		Region string `env:"NESTED_REGION"`
+	}
+	type Inner struct {
+		Host string `env:"NESTED_HOST"`
+	}
+	type Config struct {
+		Embedded
+		Database Inner
+		Legacy   Inner `env:"-"`
+		internal Inner
+		Endpoint url.URL
+		Token    SecretString
+	}
+
+	var names []string
+	for _, fi := range structFields(reflect.TypeOf(Config{})) {
+		names = append(names, fi.path())
+	}
+	expected := []string{"Region", "Database.Host"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected fields %v, got %v", expected, names)
+	}
+
+	t.Setenv("NESTED_HOST", "db.local")
+	var cfg Config
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Database.Host != "db.local" || cfg.Legacy.Host != "" {
+		t.Errorf("Expected only Database.Host to be loaded, got %+v / %+v", cfg.Database, cfg.Legacy)
+	}
+}
@@ -0,0 +1,220 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configValue é um valor folha de um arquivo de configuração, com a linha em que foi definido.
+type configValue struct {
+	value string
+	line  int
+
+	// items são os itens de um array, mantidos separados para que itens com vírgula
+	// não sejam divididos em campos lista; value tem os itens unidos por vírgula.
+	items []string
+}
+
+// listValue cria o valor de um array com os itens informados.
+func listValue(items []string, line int) configValue {
+	return configValue{value: strings.Join(items, ","), line: line, items: items}
+}
+
+// FileSource é uma Source lida de um arquivo de configuração estruturado (YAML, JSON
+// ou TOML).
+//
+// Cada campo é procurado pelo caminho da chave na tag do formato, se houver
+// (`yaml:"host"`, `json:"host"` ou `toml:"host"` dentro de uma struct Database resolve
+// "database.host", como em yaml.v3 e encoding/json), ou pelo nome da variável derivado
+// das chaves aninhadas: "database.host" (ou "database.max-conns") é encontrado como
+// DATABASE_HOST (DATABASE_MAX_CONNS). O prefixo de LoadOptions não se aplica a
+// arquivos de configuração.
+//
+// Objetos aninhados correspondem a structs aninhadas e arrays correspondem a campos
+// []string, item a item (um item pode conter vírgulas). Em Lookup e em campos que não
+// são listas, os itens são unidos por vírgula.
+//
+// Em Provenance e Trace, a origem de cada valor é o arquivo e a linha (OriginFile).
+type FileSource struct {
+	path   string
+	format string
+
+	// values são os valores pelo caminho da chave, ex: "database.host".
+	values map[string]configValue
+
+	// names mapeia o nome de variável derivado para o caminho da chave.
+	names map[string]string
+}
+
+// NewYAMLSource lê um arquivo YAML como Source. É suportado o subconjunto usado em
+// arquivos de configuração: mapas aninhados por indentação, listas (em bloco ou
+// [a, b]), strings com ou sem aspas, blocos | e > e comentários. Âncoras, tags e
+// múltiplos documentos são rejeitados.
+//
+// Parâmetros:
+//   - path: Caminho do arquivo YAML
+//
+// Exemplo:
+//
+//	src, err := NewYAMLSource("config.yaml")
+//	if err != nil {
+//	    log.Fatal(err) // config.yaml:7: duplicate key "host"
+//	}
+//	err = Load(&cfg, WithSource(src))
+//
+// Retorna:
+//   - *FileSource: Fonte com os valores do arquivo
+//   - error: Erro se o arquivo não puder ser lido ou tiver erro de sintaxe (com a linha)
+func NewYAMLSource(path string) (*FileSource, error) {
+	return readFileSource(path, "yaml", parseYAML)
+}
+
+// NewJSONSource lê um arquivo JSON como Source. O valor de topo deve ser um objeto;
+// números são mantidos como escritos e null indica ausência de valor. Listas de
+// objetos não são suportadas.
+//
+// Parâmetros:
+//   - path: Caminho do arquivo JSON
+//
+// Retorna:
+//   - *FileSource: Fonte com os valores do arquivo
+//   - error: Erro se o arquivo não puder ser lido ou tiver erro de sintaxe (com a linha)
+func NewJSONSource(path string) (*FileSource, error) {
+	return readFileSource(path, "json", parseJSON)
+}
+
+// NewTOMLSource lê um arquivo TOML como Source. São suportados tabelas, chaves com
+// pontos, tabelas inline, strings básicas e literais (inclusive de várias linhas),
+// números, booleanos, datas e arrays de valores. Arrays de tabelas ([[x]]) não são
+// suportados.
+//
+// Parâmetros:
+//   - path: Caminho do arquivo TOML
+//
+// Retorna:
+//   - *FileSource: Fonte com os valores do arquivo
+//   - error: Erro se o arquivo não puder ser lido ou tiver erro de sintaxe (com a linha)
+func NewTOMLSource(path string) (*FileSource, error) {
+	return readFileSource(path, "toml", parseTOML)
+}
+
+// NewFileSource lê um arquivo de configuração no formato indicado pela extensão:
+// .yaml ou .yml (NewYAMLSource), .json (NewJSONSource) ou .toml (NewTOMLSource).
+//
+// Parâmetros:
+//   - path: Caminho do arquivo
+//
+// Exemplo:
+//
+//	src, err := NewFileSource("config.toml")
+//
+// Retorna:
+//   - *FileSource: Fonte com os valores do arquivo
+//   - error: Erro se a extensão não for suportada, se o arquivo não puder ser lido ou
+//     se tiver erro de sintaxe
+func NewFileSource(path string) (*FileSource, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		return NewYAMLSource(path)
+	case ".json":
+		return NewJSONSource(path)
+	case ".toml":
+		return NewTOMLSource(path)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q: %s", ext, path)
+	}
+}
+
+// readFileSource lê o arquivo e o interpreta com parse.
+func readFileSource(path, format string, parse func(file, content string) (map[string]configValue, error)) (*FileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parse(path, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return newFileSource(path, format, values), nil
+}
+
+// newFileSource cria a Source a partir dos valores por caminho de chave.
+func newFileSource(path, format string, values map[string]configValue) *FileSource {
+	names := make(map[string]string, len(values))
+	for keyPath := range values {
+		names[derivedName(keyPath)] = keyPath
+	}
+	return &FileSource{path: path, format: format, values: values, names: names}
+}
+
+// derivedName converte um caminho de chave no nome de variável correspondente:
+// "database.max-conns" → "DATABASE_MAX_CONNS".
+func derivedName(keyPath string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(keyPath))
+}
+
+// Lookup retorna o valor da chave cujo nome derivado é name, ex: DATABASE_HOST.
+func (s *FileSource) Lookup(name string) (string, bool) {
+	keyPath, ok := s.names[name]
+	if !ok {
+		return "", false
+	}
+	return s.values[keyPath].value, true
+}
+
+// LookupPath retorna o valor pelo caminho da chave, ex: "database.host".
+func (s *FileSource) LookupPath(keyPath string) (string, bool) {
+	v, ok := s.values[keyPath]
+	return v.value, ok
+}
+
+// String retorna o caminho do arquivo.
+func (s *FileSource) String() string {
+	return s.path
+}
+
+// lookupField procura o campo pelo caminho da tag do formato ou pelo nome da variável
+// e retorna a origem com o arquivo e a linha em que o valor foi definido.
+func (s *FileSource) lookupField(fi fieldInfo) (string, Origin, error) {
+	v, ok := s.fieldValue(fi)
+	if !ok {
+		return "", Origin{}, nil
+	}
+	return v.value, Origin{Kind: OriginFile, File: s.path, Line: v.line}, nil
+}
+
+// lookupItems retorna os itens do campo, se o valor for um array.
+func (s *FileSource) lookupItems(fi fieldInfo) ([]string, bool) {
+	v, ok := s.fieldValue(fi)
+	return v.items, ok && v.items != nil
+}
+
+// fieldValue procura o valor do campo pelo caminho da tag do formato ou pelo nome da variável.
+func (s *FileSource) fieldValue(fi fieldInfo) (configValue, bool) {
+	keyPath, ok := fi.keyPath(s.format)
+	if !ok {
+		keyPath, ok = s.names[fi.name]
+	}
+	v, found := s.values[keyPath]
+	return v, ok && found
+}
+
+// loadConfigFiles lê os arquivos de LoadOptions.ConfigFiles e os acrescenta às Sources
+// do loader, antes das Sources informadas, com o último arquivo tendo precedência.
+func (l *loader) loadConfigFiles(files []string) error {
+	sources := make([]Source, 0, len(files)+len(l.sources))
+	for i := len(files) - 1; i >= 0; i-- {
+		src, err := NewFileSource(files[i])
+		if err != nil {
+			l.tracef("failed to load config file %s: %v", files[i], err)
+			return fmt.Errorf("error loading config file: %w", err)
+		}
+		l.tracef("loaded config file %s", files[i])
+		l.logger.Info("loaded config file", "path", files[i])
+		sources = append(sources, src)
+	}
+	l.sources = append(sources, l.sources...)
+	return nil
+}
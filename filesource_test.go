@@ -0,0 +1,237 @@
+package configloader
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// FileConfig struct para testes de arquivos de configuração
+type FileConfig struct {
+	Name     string   `env:"APP_NAME,default"`
+	Port     int      `env:"FILE_PORT,8080"`
+	Hosts    []string `env:"FILE_HOSTS"`
+	Database struct {
+		Host     string `env:"FILE_DB_HOST" yaml:"host"`
+		MaxConns int    `env:"FILE_DB_MAX_CONNS,5"`
+	} `yaml:"db"`
+}
+
+// TestNewYAMLSource testa a resolução por caminho da tag e por nome derivado
+func TestNewYAMLSource(t *testing.T) {
+	path := writeEnvFile(t, "config.yaml", `app:
+  name: from-yaml
+file_port: 9090
+file:
+  hosts: [a, b]
+  db:
+    max-conns: 20
+db:
+  host: db.yaml
+`)
+
+	src, err := NewYAMLSource(path)
+	if err != nil {
+		t.Fatalf("NewYAMLSource failed: %v", err)
+	}
+	if v, ok := src.LookupPath("db.host"); !ok || v != "db.yaml" {
+		t.Errorf("Expected db.host 'db.yaml', got '%s'", v)
+	}
+	if v, ok := src.Lookup("FILE_DB_MAX_CONNS"); !ok || v != "20" {
+		t.Errorf("Expected FILE_DB_MAX_CONNS '20', got '%s'", v)
+	}
+
+	var cfg FileConfig
+	if err := LoadFromSource(&cfg, src); err != nil {
+		t.Fatalf("LoadFromSource failed: %v", err)
+	}
+	if cfg.Name != "from-yaml" || cfg.Port != 9090 || cfg.Database.MaxConns != 20 {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+	if len(cfg.Hosts) != 2 || cfg.Hosts[1] != "b" {
+		t.Errorf("Expected Hosts [a b], got %v", cfg.Hosts)
+	}
+	if cfg.Database.Host != "db.yaml" {
+		t.Errorf("Expected Database.Host from yaml tag path, got '%s'", cfg.Database.Host)
+	}
+}
+
+// TestNewYAMLSource_ParseError testa que erros de sintaxe informam arquivo e linha
+func TestNewYAMLSource_ParseError(t *testing.T) {
+	path := writeEnvFile(t, "bad.yaml", "port: 1\nport: 2\n")
+
+	var cfg FileConfig
+	err := Load(&cfg, WithConfigFile(path))
+	if err == nil || !strings.Contains(err.Error(), "error loading config file: "+path+`:2: duplicate key "port"`) {
+		t.Errorf("Expected parse error with line, got %v", err)
+	}
+
+	if err := Load(&cfg, WithConfigFile("config.ini")); err == nil || !strings.Contains(err.Error(), `unsupported config file format ".ini"`) {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
+
+// TestWithConfigFile_Precedence testa a precedência: sistema > .env > arquivos de
+// configuração (o último vence) > Sources > defaults, e a origem com arquivo e linha
+func TestWithConfigFile_Precedence(t *testing.T) {
+	t.Setenv("APP_NAME", "system")
+	envFile := writeEnvFile(t, "precedence.env", "FILE_PORT=7000\n", "FILE_PORT")
+	base := writeEnvFile(t, "base.yaml", "file_port: 1\nfile_hosts: [base]\ndb:\n  host: base.db\n")
+	override := writeEnvFile(t, "override.yml", "file_hosts:\n  - override\n")
+
+	var prov Provenance
+	var trace bytes.Buffer
+	var cfg FileConfig
+	err := Load(&cfg,
+		WithFiles(envFile),
+		WithConfigFile(base, override),
+		WithSource(MapSource{"FILE_DB_MAX_CONNS": "9", "FILE_HOSTS": "source"}),
+		WithProvenance(&prov),
+		WithTrace(&trace),
+	)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Name != "system" || cfg.Port != 7000 || cfg.Database.MaxConns != 9 {
+		t.Errorf("Unexpected values: %+v", cfg)
+	}
+	if len(cfg.Hosts) != 1 || cfg.Hosts[0] != "override" {
+		t.Errorf("Expected last config file to win, got %v", cfg.Hosts)
+	}
+
+	origin, _ := prov.Lookup("FILE_DB_HOST")
+	if origin.Kind != OriginFile || origin.String() != base+":4" {
+		t.Errorf("Expected origin %s:4, got %s (%s)", base, origin, origin.Kind)
+	}
+	if origin, _ := prov.Lookup("FILE_HOSTS"); origin.String() != override+":2" {
+		t.Errorf("Expected origin %s:2, got %s", override, origin)
+	}
+	if !strings.Contains(trace.String(), "FILE_DB_HOST: found in config file ("+base+":4)") {
+		t.Errorf("Expected config file in trace, got:\n%s", trace.String())
+	}
+}
+
+// TestFieldKeyPath testa o caminho da chave montado a partir das tags
+func TestFieldKeyPath(t *testing.T) {
+	type Inner struct {
+		Value string `env:"KP_VALUE" yaml:"value,omitempty"`
+		Plain string `env:"KP_PLAIN"`
+	}
+	type Config struct {
+		Server struct {
+			Inner Inner `yaml:"inner"`
+		}
+		Skipped string `env:"KP_SKIPPED" yaml:"-"`
+	}
+
+	fields := structFields(reflect.TypeFor[Config]())
+	if path, ok := fields[0].keyPath("yaml"); !ok || path != "server.inner.value" {
+		t.Errorf("Expected 'server.inner.value', got '%s'", path)
+	}
+	for _, fi := range fields[1:] {
+		if path, ok := fi.keyPath("yaml"); ok {
+			t.Errorf("Expected no key path for %s, got '%s'", fi.name, path)
+		}
+	}
+}
+
+// TestWithConfigFile_JSONAndTOML testa arquivos JSON e TOML em camadas, com o formato
+// escolhido pela extensão e tags do formato para structs aninhadas
+func TestWithConfigFile_JSONAndTOML(t *testing.T) {
+	type Config struct {
+		Port   int `env:"LAYER_PORT,80" json:"port" toml:"port"`
+		Server struct {
+			Hosts   []string `env:"LAYER_HOSTS" json:"hosts" toml:"hosts"`
+			Timeout string   `env:"LAYER_TIMEOUT,1s"`
+		} `json:"server" toml:"server"`
+	}
+
+	jsonFile := writeEnvFile(t, "base.json", `{
+  "port": 8080,
+  "server": {"hosts": ["a", "b"], "timeout": "5s"}
+}`)
+	tomlFile := writeEnvFile(t, "override.toml", "[server]\nhosts = [\"c\"]\n")
+
+	var prov Provenance
+	var cfg Config
+	if err := Load(&cfg, WithoutSystemEnv(), WithConfigFile(jsonFile, tomlFile), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("Expected Port 8080 from JSON, got %d", cfg.Port)
+	}
+	if len(cfg.Server.Hosts) != 1 || cfg.Server.Hosts[0] != "c" {
+		t.Errorf("Expected Hosts [c] from TOML, got %v", cfg.Server.Hosts)
+	}
+	if cfg.Server.Timeout != "1s" {
+		t.Errorf("Expected default Timeout (key server.timeout derives SERVER_TIMEOUT), got '%s'", cfg.Server.Timeout)
+	}
+	if origin, _ := prov.Lookup("LAYER_HOSTS"); origin.String() != tomlFile+":2" {
+		t.Errorf("Expected origin %s:2, got %s", tomlFile, origin)
+	}
+	if origin, _ := prov.Lookup("LAYER_PORT"); origin.String() != jsonFile+":2" {
+		t.Errorf("Expected origin %s:2, got %s", jsonFile, origin)
+	}
+}
+
+// TestNewFileSource testa a escolha do formato pela extensão
+func TestNewFileSource(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "port: 1\n",
+		"config.YML":  "port: 1\n",
+		"config.json": `{"port": 1}`,
+		"config.toml": "port = 1\n",
+	}
+	for name, content := range files {
+		src, err := NewFileSource(writeEnvFile(t, name, content))
+		if err != nil {
+			t.Errorf("%s: NewFileSource failed: %v", name, err)
+			continue
+		}
+		if v, ok := src.Lookup("PORT"); !ok || v != "1" {
+			t.Errorf("%s: Expected PORT '1', got '%s'", name, v)
+		}
+	}
+
+	if _, err := NewFileSource("missing.json"); err == nil {
+		t.Errorf("Expected error for missing file")
+	}
+}
+
+// TestFileSource_ArrayItemsWithCommas testa que arrays viram listas item a item, sem
+// dividir itens que contêm vírgulas
+func TestFileSource_ArrayItemsWithCommas(t *testing.T) {
+	type Config struct {
+		Hosts  []string `env:"HOSTS"`
+		Joined string   `env:"JOINED"`
+	}
+
+	files := map[string]string{
+		"config.json": `{"hosts": ["x,y", "z"], "joined": ["a", "b"]}`,
+		"config.toml": "hosts = [\"x,y\", \"z\"]\njoined = [\"a\", \"b\"]\n",
+		"config.yaml": "hosts:\n  - \"x,y\"\n  - z\njoined: [a, b]\n",
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := writeEnvFile(t, name, content)
+
+			var prov Provenance
+			var cfg Config
+			if err := Load(&cfg, WithConfigFile(path), WithProvenance(&prov)); err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Hosts, []string{"x,y", "z"}) {
+				t.Errorf("Expected Hosts [x,y z], got %q", cfg.Hosts)
+			}
+			if cfg.Joined != "a,b" {
+				t.Errorf("Expected array joined for string field, got '%s'", cfg.Joined)
+			}
+			if origin, _ := prov.Lookup("HOSTS"); origin.Kind != OriginFile || origin.Raw != "x,y,z" {
+				t.Errorf("Expected file origin with joined raw value, got %+v", origin)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package configloader
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagSource expõe os campos de uma struct como flags de linha de comando em um
+// flag.FlagSet. Com WithFlags, os flags informados têm precedência sobre todas as
+// outras fontes: flags > ambiente > arquivos .env > arquivos de configuração >
+// Sources > defaults. Flags não informados não alteram a resolução. Como nas variáveis
+// de ambiente, um valor vazio (--db-host=) é tratado como ausente: vale a próxima
+// fonte ou o default.
+//
+// O nome de cada flag vem da tag `flag` ou é derivado do nome da variável:
+// DB_HOST → --db-host; `flag:""` também usa o nome derivado. Use `flag:"-"` para não
+// registrar o campo. Campos bool são
+// flags booleanos (--debug equivale a --debug=true) e, em campos lista, o flag pode
+// ser repetido (--host a --host b equivale a --host a,b).
+//
+// Exemplo:
+//
+//	var cfg Config
+//	flags, err := RegisterFlags(flag.CommandLine, &cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	flag.Parse()
+//	err = Load(&cfg, WithFlags(flags))
+type FlagSource struct {
+	// values são os flags registrados pelo nome da variável (sem prefixo).
+	values map[string]*flagValue
+}
+
+// flagValue é o flag.Value de um campo: guarda o valor informado e se o flag foi usado.
+type flagValue struct {
+	name   string
+	def    string
+	value  string
+	set    bool
+	isBool bool
+	list   bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	if v.set {
+		return v.value
+	}
+	return v.def
+}
+
+func (v *flagValue) Set(value string) error {
+	if v.list && v.set && value != "" {
+		value = v.value + "," + value
+	}
+	v.value = value
+	v.set = true
+	return nil
+}
+
+// IsBoolFlag permite usar flags de campos bool sem valor (--debug).
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// RegisterFlags registra em fs um flag para cada campo com tag `env` de config.
+// A ajuda de cada flag usa a tag `desc`, o nome da variável e o default da tag.
+// Os valores são lidos depois de fs.Parse, por Load com WithFlags.
+//
+// Parâmetros:
+//   - fs: FlagSet onde os flags são registrados (ex: flag.CommandLine)
+//   - config: Struct ou ponteiro para struct com tags `env`
+//
+// Retorna:
+//   - *FlagSource: Fonte com os valores dos flags, para WithFlags
+//   - error: Erro se config não for uma struct ou se um nome de flag já estiver em uso
+func RegisterFlags(fs *flag.FlagSet, config any) (*FlagSource, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &FlagSource{values: make(map[string]*flagValue, len(fields))}
+	for _, fi := range fields {
+		name, ok := flagName(fi)
+		if !ok {
+			continue
+		}
+		if fs.Lookup(name) != nil {
+			return nil, fmt.Errorf("flag -%s already defined (field %s)", name, fi.path())
+		}
+
+		def, _ := fi.defaultValue()
+		kind := fi.field.Type.Kind()
+		value := &flagValue{
+			name:   name,
+			def:    def,
+			isBool: kind == reflect.Bool,
+			list:   kind == reflect.Slice,
+		}
+		fs.Var(value, name, flagUsage(fi))
+		src.values[fi.name] = value
+	}
+	return src, nil
+}
+
+// flagName retorna o nome do flag do campo: a tag `flag` ou, se ela não existir ou
+// estiver vazia, o nome da variável em minúsculas com hífens. Retorna false para `flag:"-"`.
+func flagName(fi fieldInfo) (string, bool) {
+	switch name := fi.field.Tag.Get("flag"); name {
+	case "-":
+		return "", false
+	case "":
+		return strings.ReplaceAll(strings.ToLower(fi.name), "_", "-"), true
+	default:
+		return name, true
+	}
+}
+
+// flagUsage retorna a ajuda do flag: a descrição (tag `desc`) e a variável equivalente.
+func flagUsage(fi fieldInfo) string {
+	if desc := fi.field.Tag.Get("desc"); desc != "" {
+		return fmt.Sprintf("%s (env %s)", desc, fi.name)
+	}
+	return fmt.Sprintf("sets %s", fi.name)
+}
+
+// Lookup retorna o valor do flag correspondente à variável name, se o flag foi informado
+// com um valor não vazio.
+func (s *FlagSource) Lookup(name string) (string, bool) {
+	if v, ok := s.values[name]; ok && v.set && v.value != "" {
+		return v.value, true
+	}
+	return "", false
+}
+
+// lookupField retorna o valor e o nome do flag do campo, se o flag foi informado.
+func (s *FlagSource) lookupField(fi fieldInfo) (string, string, bool) {
+	v, ok := s.values[fi.name]
+	if !ok || !v.set {
+		return "", "", false
+	}
+	return v.value, v.name, true
+}
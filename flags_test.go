@@ -0,0 +1,149 @@
+package configloader
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+// FlagConfig struct para testes de flags
+type FlagConfig struct {
+	Host    string   `env:"FLAG_DB_HOST,localhost" desc:"Host do banco"`
+	Port    int      `env:"FLAG_DB_PORT,5432" flag:"port"`
+	Debug   bool     `env:"FLAG_DEBUG,false"`
+	Hosts   []string `env:"FLAG_HOSTS"`
+	Skipped string   `env:"FLAG_SKIPPED" flag:"-"`
+	Region  string   `env:"FLAG_REGION" flag:""`
+}
+
+// newFlagSet cria um FlagSet silencioso com os flags de FlagConfig
+func newFlagSet(t *testing.T) (*flag.FlagSet, *FlagSource) {
+	t.Helper()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	flags, err := RegisterFlags(fs, &FlagConfig{})
+	if err != nil {
+		t.Fatalf("RegisterFlags failed: %v", err)
+	}
+	return fs, flags
+}
+
+// TestRegisterFlags testa os nomes, defaults e ajuda dos flags registrados
+func TestRegisterFlags(t *testing.T) {
+	fs, _ := newFlagSet(t)
+
+	tests := map[string]string{
+		"flag-db-host": "localhost",
+		"port":         "5432",
+		"flag-debug":   "false",
+		"flag-hosts":   "",
+		"flag-region":  "",
+	}
+	for name, def := range tests {
+		f := fs.Lookup(name)
+		if f == nil {
+			t.Errorf("Expected flag -%s to be registered", name)
+			continue
+		}
+		if f.DefValue != def {
+			t.Errorf("Flag -%s: Expected default '%s', got '%s'", name, def, f.DefValue)
+		}
+	}
+	if fs.Lookup("flag-skipped") != nil {
+		t.Errorf("Expected flag:\"-\" field to be skipped")
+	}
+	if usage := fs.Lookup("flag-db-host").Usage; usage != "Host do banco (env FLAG_DB_HOST)" {
+		t.Errorf("Unexpected usage: %s", usage)
+	}
+
+	if _, err := RegisterFlags(fs, &FlagConfig{}); err == nil || !strings.Contains(err.Error(), "already defined") {
+		t.Errorf("Expected duplicate flag error, got %v", err)
+	}
+}
+
+// TestWithFlags_Precedence testa que flags informados vencem o ambiente e os não
+// informados não alteram a resolução
+func TestWithFlags_Precedence(t *testing.T) {
+	t.Setenv("FLAG_DB_HOST", "env.local")
+	t.Setenv("FLAG_DB_PORT", "6000")
+
+	fs, flags := newFlagSet(t)
+	if err := fs.Parse([]string{"--flag-db-host", "flag.local", "--flag-debug", "--flag-hosts", "a", "--flag-hosts=b,c"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var prov Provenance
+	var cfg FlagConfig
+	if err := Load(&cfg, WithFlags(flags), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Host != "flag.local" {
+		t.Errorf("Expected Host from flag, got '%s'", cfg.Host)
+	}
+	if cfg.Port != 6000 {
+		t.Errorf("Expected Port from environment, got %d", cfg.Port)
+	}
+	if !cfg.Debug {
+		t.Errorf("Expected Debug true from boolean flag")
+	}
+	if strings.Join(cfg.Hosts, " ") != "a b c" {
+		t.Errorf("Expected Hosts [a b c] from repeated flag, got %v", cfg.Hosts)
+	}
+
+	origin, _ := prov.Lookup("FLAG_DB_HOST")
+	if origin.Kind != OriginFlag || origin.String() != "flag --flag-db-host" {
+		t.Errorf("Expected flag origin, got %s (%s)", origin, origin.Kind)
+	}
+}
+
+// TestWithFlags_EmptyValue testa que um flag informado vazio é tratado como ausente,
+// como uma variável de ambiente vazia
+func TestWithFlags_EmptyValue(t *testing.T) {
+	t.Setenv("FLAG_DB_HOST", "env.local")
+
+	fs, flags := newFlagSet(t)
+	if err := fs.Parse([]string{"--flag-db-host="}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var trace strings.Builder
+	var cfg FlagConfig
+	if err := Load(&cfg, WithFlags(flags), WithTrace(&trace)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "env.local" {
+		t.Errorf("Expected Host from environment, got '%s'", cfg.Host)
+	}
+	if _, ok := flags.Lookup("FLAG_DB_HOST"); ok {
+		t.Errorf("Expected empty flag to be reported as not set")
+	}
+	if !strings.Contains(trace.String(), "FLAG_DB_HOST: flag --flag-db-host is empty, treated as not set") {
+		t.Errorf("Expected empty flag in trace, got:\n%s", trace.String())
+	}
+}
+
+// TestWithFlags_Prefix testa que os flags usam o nome da tag, sem o prefixo
+func TestWithFlags_Prefix(t *testing.T) {
+	fs, flags := newFlagSet(t)
+	if err := fs.Parse([]string{"-port=7000"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	cfg, err := LoadAs[FlagConfig](WithPrefix("APP_"), WithFlags(flags))
+	if err != nil {
+		t.Fatalf("LoadAs failed: %v", err)
+	}
+	if cfg.Port != 7000 {
+		t.Errorf("Expected Port 7000, got %d", cfg.Port)
+	}
+
+	if v, ok := flags.Lookup("FLAG_DB_PORT"); !ok || v != "7000" {
+		t.Errorf("Expected Lookup to return the flag value, got '%s'", v)
+	}
+	if _, ok := flags.Lookup("FLAG_DB_HOST"); ok {
+		t.Errorf("Expected unset flag to be absent")
+	}
+}
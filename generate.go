@@ -0,0 +1,279 @@
+package configloader
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// GenerateEnvExample gera um arquivo .env.example comentado a partir da struct de configuração.
+// Para cada variável são gerados comentários com a descrição (tag `desc`), o tipo,
+// o default e se é obrigatória, seguidos da linha NOME=default.
+// Campos sensíveis nunca têm o default preenchido. Structs aninhadas geram um
+// cabeçalho de seção; os campos de primeiro nível vêm antes de todas as seções.
+//
+// Parâmetros:
+//   - config: Struct (ou ponteiro) com tags `env`
+//
+// Exemplo:
+//
+//	type Config struct {
+//	    Port       string `env:"PORT,8080" desc:"Porta HTTP do servidor"`
+//	    DBPassword string `env:"DB_PASSWORD,required" desc:"Senha do banco"`
+//	}
+//
+//	out, _ := GenerateEnvExample(Config{})
+//	// # Porta HTTP do servidor
+//	// # Type: string | Default: 8080
+//	// PORT=8080
+//	//
+//	// # Senha do banco
+//	// # Type: string | Required | Sensitive
+//	// DB_PASSWORD=
+//
+// Retorna:
+//   - string: Conteúdo do .env.example
+//   - error: Erro se config não for uma struct
+func GenerateEnvExample(config any) (string, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return "", err
+	}
+
+	var mask MaskOptions
+	var b strings.Builder
+	currentGroup := ""
+	for i, fi := range fieldsBySection(fields) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if group := strings.Join(fi.groups, "."); group != currentGroup {
+			if group != "" {
+				fmt.Fprintf(&b, "# ===== %s =====\n", group)
+			}
+			currentGroup = group
+		}
+
+		if desc := fi.field.Tag.Get("desc"); desc != "" {
+			fmt.Fprintf(&b, "# %s\n", desc)
+		}
+
+		sensitive := mask.isSensitiveField(fi)
+		details := []string{"Type: " + typeName(fi.field.Type)}
+		defaultValue, hasDefault := fi.defaultValue()
+		if hasDefault && !sensitive {
+			details = append(details, "Default: "+defaultValue)
+		}
+		if fi.required() {
+			details = append(details, "Required")
+		}
+		if sensitive {
+			details = append(details, "Sensitive")
+		}
+		fmt.Fprintf(&b, "# %s\n", strings.Join(details, " | "))
+
+		value := ""
+		if hasDefault && defaultValue != "" && !sensitive {
+			value = quoteDotenv(defaultValue)
+		}
+		fmt.Fprintf(&b, "%s=%s\n", fi.name, value)
+	}
+
+	return b.String(), nil
+}
+
+// GenerateReference gera uma tabela Markdown de referência com todas as variáveis
+// da struct: nome, tipo, default, obrigatoriedade e descrição (tag `desc`).
+// Defaults de campos sensíveis são mascarados.
+//
+// Parâmetros:
+//   - config: Struct (ou ponteiro) com tags `env`
+//
+// Exemplo:
+//
+//	out, _ := GenerateReference(Config{})
+//	// | Variable | Type | Default | Required | Description |
+//	// |----------|------|---------|----------|-------------|
+//	// | `PORT` | string | `8080` | no | Porta HTTP do servidor |
+//
+// Retorna:
+//   - string: Tabela Markdown
+//   - error: Erro se config não for uma struct
+func GenerateReference(config any) (string, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return "", err
+	}
+
+	var mask MaskOptions
+	var b strings.Builder
+	b.WriteString("| Variable | Type | Default | Required | Description |\n")
+	b.WriteString("|----------|------|---------|----------|-------------|\n")
+	for _, fi := range fields {
+		defaultValue, _ := fi.defaultValue()
+		if defaultValue != "" && mask.isSensitiveField(fi) {
+			defaultValue = maskedValue
+		}
+
+		required := "no"
+		if fi.required() {
+			required = "yes"
+		}
+
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+			fi.name,
+			typeName(fi.field.Type),
+			markdownCode(defaultValue),
+			required,
+			strings.ReplaceAll(fi.field.Tag.Get("desc"), "|", `\|`),
+		)
+	}
+
+	return b.String(), nil
+}
+
+// Usage gera um texto de ajuda com todas as configurações da struct, no estilo de
+// flag.PrintDefaults, para a saída de --help: nome da variável (e do flag, com
+// WithFlags), tipo, descrição (tag `desc`), default, se é obrigatória, restrições
+// enum/min/max e se é sensível. Defaults de campos sensíveis não são exibidos.
+// Structs aninhadas geram um cabeçalho de seção; os campos de primeiro nível vêm
+// primeiro, sob "Settings:", mesmo os declarados depois de uma struct aninhada.
+//
+// Das opções, são usados Prefix, Flags e Mask.
+//
+// Parâmetros:
+//   - config: Struct (ou ponteiro) com tags `env`
+//   - opts: Opções de carga (ver Option)
+//
+// Exemplo:
+//
+//	flag.Usage = func() {
+//	    usage, _ := Usage(&cfg, WithFlags(flags))
+//	    fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n%s", os.Args[0], usage)
+//	}
+//	// Settings:
+//	//   PORT, --port int
+//	//     	Porta HTTP do servidor (default "8080"; min 1; max 65535)
+//	//   DB_PASSWORD, --db-password string
+//	//     	Senha do banco (required; sensitive)
+//
+// Retorna:
+//   - string: Texto de ajuda
+//   - error: Erro se config não for uma struct
+func Usage(config any, opts ...Option) (string, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return "", err
+	}
+	options := resolveOptions(opts)
+
+	var b strings.Builder
+	b.WriteString("Settings:\n")
+	currentGroup := ""
+	for _, fi := range fieldsBySection(fields) {
+		if group := strings.Join(fi.groups, "."); group != currentGroup {
+			fmt.Fprintf(&b, "\n%s:\n", group)
+			currentGroup = group
+		}
+
+		name := options.Prefix + fi.name
+		if options.Flags != nil {
+			if v, ok := options.Flags.values[fi.name]; ok {
+				name += ", --" + v.name
+			}
+		}
+		fmt.Fprintf(&b, "  %s %s\n", name, typeName(fi.field.Type))
+
+		sensitive := options.Mask.isSensitiveField(fi)
+		var details []string
+		if defaultValue, ok := fi.defaultValue(); ok && defaultValue != "" && !sensitive {
+			details = append(details, fmt.Sprintf("default %q", defaultValue))
+		}
+		if fi.required() {
+			details = append(details, "required")
+		}
+		if enum, ok := fi.field.Tag.Lookup("enum"); ok {
+			details = append(details, "one of: "+strings.Join(parseStringSlice(enum), ", "))
+		}
+		for _, bound := range []string{"min", "max"} {
+			if limit, ok := fi.field.Tag.Lookup(bound); ok {
+				details = append(details, bound+" "+limit)
+			}
+		}
+		if sensitive {
+			details = append(details, "sensitive")
+		}
+
+		line := fi.field.Tag.Get("desc")
+		if len(details) > 0 {
+			line = strings.TrimSpace(line + " (" + strings.Join(details, "; ") + ")")
+		}
+		if line != "" {
+			fmt.Fprintf(&b, "    \t%s\n", line)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// fieldsBySection ordena os campos por seção, mantendo a ordem da struct dentro de cada
+// uma: primeiro os de primeiro nível, depois cada grupo na ordem em que aparece.
+func fieldsBySection(fields []fieldInfo) []fieldInfo {
+	return bySection(fields, func(fi fieldInfo) fieldInfo { return fi })
+}
+
+// bySection funciona como fieldsBySection para itens que carregam um fieldInfo, como
+// as entradas de Dump.
+func bySection[T any](items []T, field func(T) fieldInfo) []T {
+	order := map[string]int{"": 0}
+	for _, item := range items {
+		if group := strings.Join(field(item).groups, "."); order[group] == 0 && group != "" {
+			order[group] = len(order)
+		}
+	}
+
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		return order[strings.Join(field(a).groups, ".")] - order[strings.Join(field(b).groups, ".")]
+	})
+	return sorted
+}
+
+// configFields retorna os campos com tag `env` de uma struct ou ponteiro para struct.
+func configFields(config any) ([]fieldInfo, error) {
+	t := reflect.TypeOf(config)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a struct or a pointer to a struct")
+	}
+	return structFields(t), nil
+}
+
+// typeName retorna um nome legível para o tipo de um campo, como usado na documentação:
+// string, int, float, bool, duration, url, list ou o tipo interno de Secret[T].
+func typeName(t reflect.Type) string {
+	if isSecretType(t) {
+		return typeName(t.Field(0).Type)
+	}
+
+	switch t {
+	case durationType:
+		return "duration"
+	case urlType, urlPtrType:
+		return "url"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice:
+		return "list"
+	default:
+		return t.Kind().String()
+	}
+}
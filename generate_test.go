@@ -0,0 +1,201 @@
+package configloader
+
+import (
+	"flag"
+	"testing"
+	"time"
+)
+
+// DocConfig struct para testes de geração de documentação
+type DocConfig struct {
+	Port       string `env:"PORT,8080" desc:"Porta HTTP do servidor"`
+	DBPassword string `env:"DB_PASSWORD,required" desc:"Senha do banco"`
+	Database   struct {
+		Timeout time.Duration `env:"DB_TIMEOUT,5s"`
+		Hosts   []string      `env:"DB_HOSTS,a,b" desc:"Hosts | réplicas"`
+	}
+	APIToken SecretString `env:"API_TOKEN,dev-token"`
+}
+
+// TestGenerateEnvExample testa a geração do .env.example
+func TestGenerateEnvExample(t *testing.T) {
+	out, err := GenerateEnvExample(&DocConfig{})
+	if err != nil {
+		t.Fatalf("GenerateEnvExample failed: %v", err)
+	}
+
+	expected := `# Porta HTTP do servidor
+# Type: string | Default: 8080
+PORT=8080
+
+# Senha do banco
+# Type: string | Required | Sensitive
+DB_PASSWORD=
+
+# Type: string | Sensitive
+API_TOKEN=
+
+# ===== Database =====
+# Type: duration | Default: 5s
+DB_TIMEOUT=5s
+
+# Hosts | réplicas
+# Type: list | Default: a,b
+DB_HOSTS=a,b
+`
+	if out != expected {
+		t.Errorf("Unexpected .env.example:\n%s", out)
+	}
+}
+
+// TestGenerateEnvExample_Sections testa que campos de primeiro nível declarados depois de
+// uma struct aninhada não ficam sob o cabeçalho dela
+func TestGenerateEnvExample_Sections(t *testing.T) {
+	type Config struct {
+		Host     string `env:"HOST"`
+		Database struct {
+			User string `env:"DB_USER"`
+		}
+		Port int `env:"PORT"`
+	}
+
+	out, err := GenerateEnvExample(Config{})
+	if err != nil {
+		t.Fatalf("GenerateEnvExample failed: %v", err)
+	}
+
+	expected := `# Type: string
+HOST=
+
+# Type: int
+PORT=
+
+# ===== Database =====
+# Type: string
+DB_USER=
+`
+	if out != expected {
+		t.Errorf("Unexpected .env.example:\n%s", out)
+	}
+}
+
+// TestGenerateReference testa a geração da tabela Markdown
+func TestGenerateReference(t *testing.T) {
+	out, err := GenerateReference(DocConfig{})
+	if err != nil {
+		t.Fatalf("GenerateReference failed: %v", err)
+	}
+
+	expected := "| Variable | Type | Default | Required | Description |\n" +
+		"|----------|------|---------|----------|-------------|\n" +
+		"| `PORT` | string | `8080` | no | Porta HTTP do servidor |\n" +
+		"| `DB_PASSWORD` | string |  | yes | Senha do banco |\n" +
+		"| `DB_TIMEOUT` | duration | `5s` | no |  |\n" +
+		"| `DB_HOSTS` | list | `a,b` | no | Hosts \\| réplicas |\n" +
+		"| `API_TOKEN` | string | `***MASKED***` | no |  |\n"
+	if out != expected {
+		t.Errorf("Unexpected reference:\n%s", out)
+	}
+}
+
+// TestGenerateEnvExample_NonStruct testa erro com não-struct
+func TestGenerateEnvExample_NonStruct(t *testing.T) {
+	if _, err := GenerateEnvExample("config"); err == nil {
+		t.Error("Expected error for non-struct, got nil")
+	}
+}
+
+// TestUsage testa o texto de ajuda gerado a partir da struct
+func TestUsage(t *testing.T) {
+	out, err := Usage(&DocConfig{})
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+
+	expected := `Settings:
+  PORT string
+    	Porta HTTP do servidor (default "8080")
+  DB_PASSWORD string
+    	Senha do banco (required; sensitive)
+  API_TOKEN string
+    	(sensitive)
+
+Database:
+  DB_TIMEOUT duration
+    	(default "5s")
+  DB_HOSTS list
+    	Hosts | réplicas (default "a,b")
+`
+	if out != expected {
+		t.Errorf("Unexpected usage:\n%s", out)
+	}
+}
+
+// TestUsage_Sections testa que cada seção aparece uma vez, com os campos de primeiro
+// nível declarados depois de structs aninhadas sob "Settings:"
+func TestUsage_Sections(t *testing.T) {
+	type Config struct {
+		Host     string `env:"HOST"`
+		Database struct {
+			User string `env:"DB_USER"`
+			Pool struct {
+				Size int `env:"DB_POOL_SIZE"`
+			}
+			Name string `env:"DB_NAME"`
+		}
+		Port int `env:"PORT"`
+	}
+
+	out, err := Usage(Config{})
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+
+	expected := `Settings:
+  HOST string
+  PORT int
+
+Database:
+  DB_USER string
+  DB_NAME string
+
+Database.Pool:
+  DB_POOL_SIZE int
+`
+	if out != expected {
+		t.Errorf("Unexpected usage:\n%s", out)
+	}
+}
+
+// TestUsage_FlagsAndConstraints testa nomes de flags, prefixo e restrições no texto de ajuda
+func TestUsage_FlagsAndConstraints(t *testing.T) {
+	type Config struct {
+		Port  int    `env:"PORT,8080" min:"1" max:"65535" flag:"port"`
+		Level string `env:"LOG_LEVEL" enum:"debug,info"`
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags, err := RegisterFlags(fs, Config{})
+	if err != nil {
+		t.Fatalf("RegisterFlags failed: %v", err)
+	}
+
+	out, err := Usage(Config{}, WithPrefix("APP_"), WithFlags(flags))
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+
+	expected := `Settings:
+  APP_PORT, --port int
+    	(default "8080"; min 1; max 65535)
+  APP_LOG_LEVEL, --log-level string
+    	(one of: debug, info)
+`
+	if out != expected {
+		t.Errorf("Unexpected usage:\n%s", out)
+	}
+
+	if _, err := Usage(42); err == nil {
+		t.Error("Expected error for non-struct, got nil")
+	}
+}
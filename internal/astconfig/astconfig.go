@@ -0,0 +1,382 @@
+// Package astconfig lê structs de configuração (com tags `env`) diretamente do
+// código-fonte Go, sem compilar o pacote. É usado pelo comando configloader
+// (-pkg/-type) e pelo gerador configloader-gen.
+package astconfig
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+	urlPtrType   = reflect.TypeOf(&url.URL{})
+	stringsType  = reflect.TypeOf([]string{})
+)
+
+// basicTypes mapeia os nomes de tipos básicos suportados pelo configloader para reflect.Type.
+var basicTypes = map[string]reflect.Type{
+	"string":  reflect.TypeOf(""),
+	"bool":    reflect.TypeOf(false),
+	"int":     reflect.TypeOf(int(0)),
+	"int8":    reflect.TypeOf(int8(0)),
+	"int16":   reflect.TypeOf(int16(0)),
+	"int32":   reflect.TypeOf(int32(0)),
+	"int64":   reflect.TypeOf(int64(0)),
+	"float32": reflect.TypeOf(float32(0)),
+	"float64": reflect.TypeOf(float64(0)),
+}
+
+// Field é um campo de uma struct de configuração lido do código-fonte.
+type Field struct {
+	// Name é o nome do campo Go.
+	Name string
+
+	// Tag é a tag do campo, como escrita no código-fonte.
+	Tag reflect.StructTag
+
+	// Type é o tipo equivalente do valor (para Secret[T], o de T). Tipos nomeados
+	// do pacote são resolvidos para o tipo básico subjacente.
+	Type reflect.Type
+
+	// GoType é o tipo do valor como escrito no pacote, ex: "int8", "Level" ou
+	// "time.Duration". Vazio para structs anônimas.
+	GoType string
+
+	// Secret indica que o campo é um Secret[T] (ou SecretString).
+	Secret bool
+
+	// Exported indica se o campo é exportado.
+	Exported bool
+
+	// Embedded indica que o campo é uma struct embutida (anônima).
+	Embedded bool
+
+	// Fields são os campos da struct aninhada, quando o campo não tem tag `env`
+	// e é uma struct.
+	Fields []*Field
+}
+
+// HasEnv indica se o campo tem tag `env`.
+func (f *Field) HasEnv() bool {
+	return f.Tag.Get("env") != ""
+}
+
+// Package guarda as declarações de tipo de um pacote Go analisado.
+type Package struct {
+	// Name é o nome do pacote (cláusula package).
+	Name string
+
+	types map[string]typeSpec
+}
+
+// typeSpec é uma declaração de tipo com os imports do arquivo onde foi declarada.
+type typeSpec struct {
+	expr    ast.Expr
+	imports map[string]string
+}
+
+// Dir resolve um diretório ou import path (via go list) para o diretório do pacote.
+func Dir(pkg string) (string, error) {
+	if info, err := os.Stat(pkg); err == nil && info.IsDir() {
+		return pkg, nil
+	}
+
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", pkg).Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot find package %s: %w", pkg, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Parse lê os arquivos .go (exceto testes) de dir e indexa as declarações de tipo.
+// Arquivos com skip no nome base (ex: o próprio arquivo gerado) são ignorados.
+func Parse(dir string, skip ...string) (*Package, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Package{types: make(map[string]typeSpec)}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || containsString(skip, filepath.Base(file)) {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		p.Name = f.Name.Name
+
+		imports := make(map[string]string)
+		for _, imp := range f.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			name := strings.ReplaceAll(path.Base(importPath), "-", "")
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = importPath
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				ts := s.(*ast.TypeSpec)
+				p.types[ts.Name.Name] = typeSpec{expr: ts.Type, imports: imports}
+			}
+		}
+	}
+
+	if p.Name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return p, nil
+}
+
+// Struct retorna os campos da struct typeName declarada no pacote.
+func (p *Package) Struct(typeName string) ([]*Field, error) {
+	spec, ok := p.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", typeName)
+	}
+
+	st, ok := spec.expr.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a struct", typeName)
+	}
+
+	fields, err := p.structFields(st, spec.imports)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", typeName, err)
+	}
+	return fields, nil
+}
+
+// structFields converte os campos de uma struct do código-fonte. Campos sem tag `env`
+// que não são structs (ou cujo tipo não é suportado) e campos com `env:"-"` são
+// ignorados.
+func (p *Package) structFields(st *ast.StructType, imports map[string]string) ([]*Field, error) {
+	var fields []*Field
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+		structTag := reflect.StructTag(tag)
+		if structTag.Get("env") == "-" {
+			continue
+		}
+		hasEnv := structTag.Get("env") != ""
+
+		info, err := p.fieldType(field.Type, imports)
+		if err != nil {
+			if !hasEnv {
+				continue
+			}
+			return nil, fmt.Errorf("field %s: %w", fieldName(field), err)
+		}
+		if !hasEnv && info.Fields == nil {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			name := embeddedName(field.Type)
+			if hasEnv || !ast.IsExported(name) {
+				continue
+			}
+			f := *info
+			f.Name, f.Tag, f.Exported, f.Embedded = name, structTag, true, true
+			fields = append(fields, &f)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !hasEnv && !name.IsExported() {
+				continue
+			}
+			f := *info
+			f.Name, f.Tag, f.Exported = name.Name, structTag, name.IsExported()
+			fields = append(fields, &f)
+		}
+	}
+	return fields, nil
+}
+
+// fieldType resolve o tipo de um campo do código-fonte.
+func (p *Package) fieldType(expr ast.Expr, imports map[string]string) (*Field, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if t, ok := basicTypes[e.Name]; ok {
+			return &Field{Type: t, GoType: e.Name}, nil
+		}
+		if spec, ok := p.types[e.Name]; ok {
+			info, err := p.fieldType(spec.expr, spec.imports)
+			if err != nil {
+				return nil, err
+			}
+			if info.Secret {
+				return info, nil
+			}
+			switch info.Type {
+			case durationType:
+				// Um tipo nomeado sobre time.Duration é tratado pelo configloader como inteiro.
+				info.Type = basicTypes["int64"]
+			case urlType, urlPtrType:
+				return nil, fmt.Errorf("unsupported type %s", e.Name)
+			}
+			info.GoType = e.Name
+			return info, nil
+		}
+
+	case *ast.StructType:
+		fields, err := p.structFields(e, imports)
+		if err != nil {
+			return nil, err
+		}
+		if fields == nil {
+			fields = []*Field{}
+		}
+		return &Field{Type: StructType(fields), Fields: fields}, nil
+
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && e.Len == nil && ident.Name == "string" {
+			return &Field{Type: stringsType, GoType: "[]string"}, nil
+		}
+
+	case *ast.StarExpr:
+		if info, err := p.fieldType(e.X, imports); err == nil && info.Type == urlType {
+			return &Field{Type: urlPtrType, GoType: "*" + info.GoType}, nil
+		}
+
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		switch importPath := imports[pkg.Name]; {
+		case importPath == "time" && e.Sel.Name == "Duration":
+			return &Field{Type: durationType, GoType: exprString(e)}, nil
+		case importPath == "net/url" && e.Sel.Name == "URL":
+			return &Field{Type: urlType, GoType: exprString(e)}, nil
+		case isConfigLoader(importPath) && e.Sel.Name == "SecretString":
+			return &Field{Type: basicTypes["string"], GoType: "string", Secret: true}, nil
+		}
+
+	case *ast.IndexExpr:
+		if sel, ok := e.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "Secret" {
+			if pkg, ok := sel.X.(*ast.Ident); ok && isConfigLoader(imports[pkg.Name]) {
+				info, err := p.fieldType(e.Index, imports)
+				if err != nil {
+					return nil, err
+				}
+				if info.Fields != nil {
+					return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+				}
+				info.Secret = true
+				return info, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+}
+
+// StructType constrói, com reflect.StructOf, uma struct equivalente aos campos.
+// Campos não exportados são omitidos, structs embutidas têm seus campos promovidos
+// e Secret[T] vira T com a tag `secret:"true"`.
+func StructType(fields []*Field) reflect.Type {
+	return reflect.StructOf(structFieldsOf(fields))
+}
+
+// structFieldsOf converte os campos em reflect.StructField para StructType.
+func structFieldsOf(fields []*Field) []reflect.StructField {
+	var result []reflect.StructField
+	for _, f := range fields {
+		if !f.Exported {
+			continue
+		}
+		if f.Embedded {
+			result = append(result, structFieldsOf(f.Fields)...)
+			continue
+		}
+
+		tag := f.Tag
+		if _, ok := tag.Lookup("secret"); f.Secret && !ok {
+			tag = reflect.StructTag(strings.TrimSpace(string(tag) + ` secret:"true"`))
+		}
+		result = append(result, reflect.StructField{Name: f.Name, Type: f.Type, Tag: tag})
+	}
+	return result
+}
+
+// isConfigLoader indica se o import path é o desta biblioteca.
+func isConfigLoader(importPath string) bool {
+	return importPath == "github.com/wesleysantana/config-loader"
+}
+
+// embeddedName retorna o nome de um campo embutido (o nome do tipo).
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// fieldName retorna o nome de um campo para mensagens de erro.
+func fieldName(field *ast.Field) string {
+	if len(field.Names) > 0 {
+		return field.Names[0].Name
+	}
+	return exprString(field.Type)
+}
+
+// exprString formata uma expressão de tipo para mensagens de erro.
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(e.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(e.Key) + "]" + exprString(e.Value)
+	case *ast.IndexExpr:
+		return exprString(e.X) + "[" + exprString(e.Index) + "]"
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+// containsString indica se value está em values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
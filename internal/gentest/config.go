@@ -0,0 +1,51 @@
+// Package gentest declara uma struct de configuração usada para verificar que o
+// código gerado por configloader-gen se comporta como o loader por reflection.
+package gentest
+
+import (
+	"net/url"
+	"time"
+
+	configloader "github.com/wesleysantana/config-loader"
+)
+
+//go:generate go run ../../cmd/configloader-gen -type Config -validate
+
+// Level é um tipo nomeado sobre string.
+type Level string
+
+// Base é embutida em Config; seus campos são promovidos.
+type Base struct {
+	AppName string `env:"APP_NAME,demo"`
+}
+
+// Database agrupa as configurações do banco.
+type Database struct {
+	Host     string                    `env:"DB_HOST,localhost"`
+	Port     int                       `env:"DB_PORT,5432" min:"1" max:"65535"`
+	Password configloader.SecretString `env:"DB_PASSWORD,required"`
+}
+
+// Config usa todos os tipos suportados pelo configloader.
+type Config struct {
+	Base
+
+	Port     int                      `env:"PORT,8080" min:"1" max:"65535"`
+	Workers  int8                     `env:"WORKERS,4"`
+	Ratio    float64                  `env:"RATIO,0.5"`
+	Weight   float32                  `env:"WEIGHT"`
+	Debug    bool                     `env:"DEBUG,false"`
+	Level    Level                    `env:"LOG_LEVEL,info" enum:"debug,info,warn,error"`
+	Timeout  time.Duration            `env:"TIMEOUT,30s" max:"5m"`
+	Hosts    []string                 `env:"HOSTS,a.local,b.local"`
+	Endpoint url.URL                  `env:"ENDPOINT"`
+	Callback *url.URL                 `env:"CALLBACK_URL"`
+	PinCode  configloader.Secret[int] `env:"PIN_CODE"`
+	Database Database
+
+	Cache struct {
+		TTL time.Duration `env:"CACHE_TTL,1m"`
+	}
+
+	internal string `env:"INTERNAL,required"`
+}
@@ -0,0 +1,128 @@
+// Code generated by configloader-gen; DO NOT EDIT.
+
+package gentest
+
+import configloader "github.com/wesleysantana/config-loader"
+
+// LoadConfig carrega Config a partir de src sem reflection, com as mesmas regras de
+// configloader.LoadFromSource com WithValidation (defaults, required, tipos e validações).
+func LoadConfig(src configloader.Source) (Config, error) {
+	var cfg Config
+	r := configloader.NewResolver(src)
+
+	if raw, ok := r.Lookup("APP_NAME", "demo"); ok {
+		cfg.Base.AppName = raw
+	}
+
+	if raw, ok := r.Lookup("PORT", "8080"); ok {
+		v, err := configloader.ParseInt(raw)
+		if err != nil {
+			return cfg, r.FieldError("Port", err)
+		}
+		cfg.Port = int(v)
+		r.Check("PORT", "Port", cfg.Port, `env:"PORT,8080" min:"1" max:"65535"`)
+	}
+
+	if raw, ok := r.Lookup("WORKERS", "4"); ok {
+		v, err := configloader.ParseInt(raw)
+		if err != nil {
+			return cfg, r.FieldError("Workers", err)
+		}
+		cfg.Workers = int8(v)
+	}
+
+	if raw, ok := r.Lookup("RATIO", "0.5"); ok {
+		v, err := configloader.ParseFloat(raw)
+		if err != nil {
+			return cfg, r.FieldError("Ratio", err)
+		}
+		cfg.Ratio = v
+	}
+
+	if raw, ok := r.Lookup("WEIGHT", ""); ok {
+		v, err := configloader.ParseFloat(raw)
+		if err != nil {
+			return cfg, r.FieldError("Weight", err)
+		}
+		cfg.Weight = float32(v)
+	}
+
+	if raw, ok := r.Lookup("DEBUG", "false"); ok {
+		v, err := configloader.ParseBool(raw)
+		if err != nil {
+			return cfg, r.FieldError("Debug", err)
+		}
+		cfg.Debug = v
+	}
+
+	if raw, ok := r.Lookup("LOG_LEVEL", "info"); ok {
+		cfg.Level = Level(raw)
+		r.Check("LOG_LEVEL", "Level", cfg.Level, `env:"LOG_LEVEL,info" enum:"debug,info,warn,error"`)
+	}
+
+	if raw, ok := r.Lookup("TIMEOUT", "30s"); ok {
+		v, err := configloader.ParseDuration(raw)
+		if err != nil {
+			return cfg, r.FieldError("Timeout", err)
+		}
+		cfg.Timeout = v
+		r.Check("TIMEOUT", "Timeout", cfg.Timeout, `env:"TIMEOUT,30s" max:"5m"`)
+	}
+
+	if raw, ok := r.Lookup("HOSTS", "a.local,b.local"); ok {
+		cfg.Hosts = configloader.ParseStringSlice(raw)
+	}
+
+	if raw, ok := r.Lookup("ENDPOINT", ""); ok {
+		v, err := configloader.ParseURL(raw)
+		if err != nil {
+			return cfg, r.FieldError("Endpoint", err)
+		}
+		cfg.Endpoint = *v
+	}
+
+	if raw, ok := r.Lookup("CALLBACK_URL", ""); ok {
+		v, err := configloader.ParseURL(raw)
+		if err != nil {
+			return cfg, r.FieldError("Callback", err)
+		}
+		cfg.Callback = v
+	}
+
+	if raw, ok := r.Lookup("PIN_CODE", ""); ok {
+		v, err := configloader.ParseInt(raw)
+		if err != nil {
+			return cfg, r.FieldError("PinCode", err)
+		}
+		cfg.PinCode = configloader.NewSecret(int(v))
+	}
+
+	if raw, ok := r.Lookup("DB_HOST", "localhost"); ok {
+		cfg.Database.Host = raw
+	}
+
+	if raw, ok := r.Lookup("DB_PORT", "5432"); ok {
+		v, err := configloader.ParseInt(raw)
+		if err != nil {
+			return cfg, r.FieldError("Database.Port", err)
+		}
+		cfg.Database.Port = int(v)
+		r.Check("DB_PORT", "Port", cfg.Database.Port, `env:"DB_PORT,5432" min:"1" max:"65535"`)
+	}
+
+	if raw, ok := r.Lookup("DB_PASSWORD", "required"); ok {
+		cfg.Database.Password = configloader.NewSecret(raw)
+	}
+
+	if raw, ok := r.Lookup("CACHE_TTL", "1m"); ok {
+		v, err := configloader.ParseDuration(raw)
+		if err != nil {
+			return cfg, r.FieldError("Cache.TTL", err)
+		}
+		cfg.Cache.TTL = v
+	}
+
+	r.Lookup("INTERNAL", "required")
+
+	return cfg, r.Err()
+}
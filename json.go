@@ -0,0 +1,158 @@
+package configloader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// jsonParser percorre os tokens de um arquivo JSON acumulando os valores folha pelo
+// caminho da chave e convertendo posições em bytes para números de linha.
+type jsonParser struct {
+	file       string
+	dec        *json.Decoder
+	lineStarts []int
+	values     map[string]configValue
+}
+
+// parseJSON interpreta o conteúdo de um arquivo JSON. Erros incluem arquivo e linha.
+func parseJSON(file, content string) (map[string]configValue, error) {
+	p := &jsonParser{
+		file:       file,
+		dec:        json.NewDecoder(strings.NewReader(content)),
+		lineStarts: []int{0},
+		values:     make(map[string]configValue),
+	}
+	p.dec.UseNumber()
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+
+	tok, err := p.token()
+	if err == io.EOF {
+		return p.values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, p.errorf("top-level value must be an object")
+	}
+	if err := p.parseObject(nil); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, p.errorf("unexpected content after the top-level object")
+	}
+	return p.values, nil
+}
+
+// line retorna a linha (a partir de 1) de uma posição em bytes.
+func (p *jsonParser) line(offset int64) int {
+	return sort.SearchInts(p.lineStarts, int(offset)+1)
+}
+
+// errorf retorna um erro com arquivo e a linha da posição atual do decoder.
+func (p *jsonParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s:%d: %s", p.file, p.line(p.dec.InputOffset()), fmt.Sprintf(format, args...))
+}
+
+// token lê o próximo token, convertendo erros de sintaxe para arquivo:linha.
+func (p *jsonParser) token() (json.Token, error) {
+	tok, err := p.dec.Token()
+	var syntaxErr *json.SyntaxError
+	switch {
+	case err == nil, err == io.EOF:
+		return tok, err
+	case errors.As(err, &syntaxErr):
+		return nil, fmt.Errorf("%s:%d: %s", p.file, p.line(syntaxErr.Offset), syntaxErr)
+	case err == io.ErrUnexpectedEOF:
+		return nil, p.errorf("unexpected end of file")
+	default:
+		return nil, p.errorf("%v", err)
+	}
+}
+
+// parseObject lê os membros de um objeto já aberto até o '}' correspondente.
+func (p *jsonParser) parseObject(path []string) error {
+	seen := make(map[string]int)
+	for p.dec.More() {
+		tok, err := p.token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		n := p.line(p.dec.InputOffset())
+		if first, dup := seen[key]; dup {
+			return p.errorf("duplicate key %q (first defined at line %d)", key, first)
+		}
+		seen[key] = n
+		childPath := append(append([]string{}, path...), key)
+
+		tok, err = p.token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			if err := p.parseObject(childPath); err != nil {
+				return err
+			}
+		case json.Delim('['):
+			if err := p.parseArray(childPath, n); err != nil {
+				return err
+			}
+		case nil:
+		default:
+			p.values[strings.Join(childPath, ".")] = configValue{value: jsonScalar(tok), line: n}
+		}
+	}
+
+	_, err := p.token()
+	return err
+}
+
+// parseArray lê uma lista de valores já aberta e guarda os itens.
+func (p *jsonParser) parseArray(path []string, n int) error {
+	var items []string
+	for p.dec.More() {
+		tok, err := p.token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			return p.errorf("only arrays of scalar values are supported")
+		case nil:
+		default:
+			items = append(items, jsonScalar(tok))
+		}
+	}
+	if _, err := p.token(); err != nil {
+		return err
+	}
+
+	p.values[strings.Join(path, ".")] = listValue(items, n)
+	return nil
+}
+
+// jsonScalar converte um token escalar (string, número ou booleano) em texto.
+func jsonScalar(tok json.Token) string {
+	switch v := tok.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,70 @@
+package configloader
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseJSON testa objetos aninhados, arrays e a linha de cada valor
+func TestParseJSON(t *testing.T) {
+	content := `{
+  "app_name": "api",
+  "port": 8080,
+  "ratio": 1.50,
+  "debug": true,
+  "database": {
+    "host": "db.internal",
+    "options": {"ssl": false}
+  },
+  "hosts": ["a", "b"],
+  "empty": null,
+  "none": {}
+}`
+	values, err := parseJSON("config.json", content)
+	if err != nil {
+		t.Fatalf("parseJSON failed: %v", err)
+	}
+
+	expected := map[string]configValue{
+		"app_name":             {value: "api", line: 2},
+		"port":                 {value: "8080", line: 3},
+		"ratio":                {value: "1.50", line: 4},
+		"debug":                {value: "true", line: 5},
+		"database.host":        {value: "db.internal", line: 7},
+		"database.options.ssl": {value: "false", line: 8},
+		"hosts":                listValue([]string{"a", "b"}, 10),
+	}
+	for key, want := range expected {
+		if got, ok := values[key]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Key %s: Expected %+v, got %+v (found: %v)", key, want, got, ok)
+		}
+	}
+	if len(values) != len(expected) {
+		t.Errorf("Expected %d values, got %d: %v", len(expected), len(values), values)
+	}
+}
+
+// TestParseJSON_Errors testa as mensagens de erro com número da linha
+func TestParseJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"syntax", "{\n  \"a\": 1,\n  \"b\" 2\n}", "config.json:3: invalid character '2' after object key"},
+		{"duplicate key", "{\n  \"a\": 1,\n  \"a\": 2\n}", `config.json:3: duplicate key "a" (first defined at line 2)`},
+		{"array of objects", "{\n  \"a\": [{\"b\": 1}]\n}", "config.json:2: only arrays of scalar values are supported"},
+		{"top-level array", "[1, 2]", "config.json:1: top-level value must be an object"},
+		{"truncated", "{\n  \"a\": 1,\n", "config.json:3: unexpected end of JSON input"},
+		{"trailing content", "{}\n{}", "config.json:2: unexpected content after the top-level object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJSON("config.json", tt.content)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Expected error '%s', got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// LintKind identifica o tipo de problema encontrado por LintEnvFile.
+type LintKind string
+
+const (
+	// LintMalformed é uma linha que não pode ser interpretada como KEY=VALUE.
+	LintMalformed LintKind = "malformed"
+
+	// LintDuplicate é uma chave definida mais de uma vez no mesmo arquivo.
+	LintDuplicate LintKind = "duplicate"
+
+	// LintUnused é uma chave que não corresponde a nenhuma tag `env` da struct.
+	LintUnused LintKind = "unused"
+
+	// LintTrailingSpace é um valor sem aspas terminado em espaços, que são descartados na carga.
+	LintTrailingSpace LintKind = "trailing-space"
+
+	// LintInvalidValue é um valor que não pode ser convertido para o tipo do campo
+	// ou que viola as tags enum/min/max.
+	LintInvalidValue LintKind = "invalid-value"
+)
+
+// LintIssue é um problema encontrado em um arquivo .env.
+type LintIssue struct {
+	// File é o caminho do arquivo analisado.
+	File string
+
+	// Line é a linha do problema, a partir de 1.
+	Line int
+
+	// Key é a chave envolvida, se a linha pôde ser interpretada.
+	Key string
+
+	// Kind é o tipo do problema.
+	Kind LintKind
+
+	// Message descreve o problema.
+	Message string
+}
+
+// String retorna o problema no formato arquivo:linha: mensagem.
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+// envEntry é uma atribuição KEY=VALUE de um arquivo .env, com a linha em que começa.
+type envEntry struct {
+	key   string
+	value string
+	line  int
+}
+
+// LintEnvFile analisa um arquivo .env sem carregá-lo no ambiente e reporta, com
+// arquivo e linha: linhas malformadas, chaves duplicadas, valores sem aspas com
+// espaços no final e, se config não for nil, chaves não usadas pela struct (com
+// sugestão do nome mais parecido) e valores que Load rejeitaria (tipo inválido
+// ou, com WithValidation, violação de enum/min/max).
+//
+// Das opções, são usados Prefix (os nomes da struct são comparados com o prefixo),
+// Validate e Mask (mensagens de campos sensíveis não incluem o valor). Valores com
+// expansão de variáveis ($VAR) não têm o tipo verificado.
+//
+// Parâmetros:
+//   - path: Caminho do arquivo .env
+//   - config: Struct ou ponteiro para struct com tags `env`, ou nil
+//   - opts: Opções de carga (ver Option)
+//
+// Exemplo:
+//
+//	issues, err := LintEnvFile(".env", &Config{}, WithPrefix("APP_"))
+//	for _, issue := range issues {
+//	    fmt.Println(issue) // .env:3: APP_DB_HSOT is not used by the config (did you mean APP_DB_HOST?)
+//	}
+//
+// Retorna:
+//   - []LintIssue: Problemas encontrados, ordenados por linha
+//   - error: Erro se o arquivo não puder ser lido ou config não for uma struct
+func LintEnvFile(path string, config any, opts ...Option) ([]LintIssue, error) {
+	options := resolveOptions(opts)
+
+	var fields []fieldInfo
+	if config != nil {
+		var err error
+		if fields, err = configFields(config); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading .env file: %w", err)
+	}
+
+	entries, issues := parseEnvEntries(path, string(data))
+	issues = append(issues, lintDuplicates(path, entries)...)
+	if config != nil {
+		issues = append(issues, lintValues(path, entries, fields, options)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues, nil
+}
+
+// parseEnvEntries interpreta o conteúdo de um arquivo .env com as regras do godotenv:
+// comentários (#), prefixo export, separadores = ou :, aspas simples (sem escapes) e
+// duplas (com escapes), ambas com várias linhas, e comentários após valores sem aspas.
+func parseEnvEntries(path, content string) ([]envEntry, []LintIssue) {
+	var entries []envEntry
+	var issues []LintIssue
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		n := i + 1
+		line := strings.TrimLeft(lines[i], " \t")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			issues = append(issues, LintIssue{File: path, Line: n, Kind: LintMalformed,
+				Message: fmt.Sprintf("expected KEY=VALUE, got %q", strings.TrimSpace(line))})
+			continue
+		}
+
+		key := strings.TrimSpace(line[:idx])
+		if !isValidEnvKey(key) {
+			issues = append(issues, LintIssue{File: path, Line: n, Key: key, Kind: LintMalformed,
+				Message: fmt.Sprintf("invalid key %q", key)})
+			continue
+		}
+
+		raw := strings.TrimLeft(line[idx+1:], " \t")
+		if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+			quote := raw[0]
+			body := raw[1:]
+			end := envClosingQuote(body, quote)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				body += "\n" + lines[i]
+				end = envClosingQuote(body, quote)
+			}
+			if end < 0 {
+				issues = append(issues, LintIssue{File: path, Line: n, Key: key, Kind: LintMalformed,
+					Message: fmt.Sprintf("unterminated quoted value for %s", key)})
+				continue
+			}
+			if after := strings.TrimSpace(body[end+1:]); after != "" && !strings.HasPrefix(after, "#") {
+				issues = append(issues, LintIssue{File: path, Line: n, Key: key, Kind: LintMalformed,
+					Message: fmt.Sprintf("unexpected text after quoted value for %s: %q", key, after)})
+				continue
+			}
+
+			value := body[:end]
+			if quote == '"' {
+				value = unescapeDoubleQuoted(value)
+			}
+			entries = append(entries, envEntry{key: key, value: value, line: n})
+			continue
+		}
+
+		value := raw
+		if j := inlineComment(value); j >= 0 {
+			value = value[:j]
+		} else if strings.TrimRight(value, " \t") != value {
+			issues = append(issues, LintIssue{File: path, Line: n, Key: key, Kind: LintTrailingSpace,
+				Message: fmt.Sprintf("unquoted value for %s has trailing whitespace; quote it or remove the spaces", key)})
+		}
+		entries = append(entries, envEntry{key: key, value: strings.TrimSpace(value), line: n})
+	}
+
+	return entries, issues
+}
+
+// lintDuplicates reporta as chaves definidas mais de uma vez.
+func lintDuplicates(path string, entries []envEntry) []LintIssue {
+	var issues []LintIssue
+	first := make(map[string]int)
+	for _, e := range entries {
+		if line, ok := first[e.key]; ok {
+			issues = append(issues, LintIssue{File: path, Line: e.line, Key: e.key, Kind: LintDuplicate,
+				Message: fmt.Sprintf("duplicate key %s (first defined at line %d)", e.key, line)})
+			continue
+		}
+		first[e.key] = e.line
+	}
+	return issues
+}
+
+// lintValues reporta as chaves não usadas pela struct e os valores que Load rejeitaria.
+func lintValues(path string, entries []envEntry, fields []fieldInfo, options LoadOptions) []LintIssue {
+	known := make(map[string]fieldInfo, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, fi := range fields {
+		name := options.Prefix + fi.name
+		known[name] = fi
+		names = append(names, name)
+		for _, old := range fi.deprecatedNames() {
+			known[options.Prefix+old] = fi
+		}
+	}
+
+	var issues []LintIssue
+	for _, e := range entries {
+		fi, ok := known[e.key]
+		if !ok {
+			message := fmt.Sprintf("%s is not used by the config", e.key)
+			if suggestion := suggestName(e.key, names); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %s?)", suggestion)
+			}
+			issues = append(issues, LintIssue{File: path, Line: e.line, Key: e.key, Kind: LintUnused, Message: message})
+			continue
+		}
+
+		if e.value == "" || strings.Contains(e.value, "$") {
+			continue
+		}
+
+		sensitive := options.Mask.isSensitiveField(fi)
+		value := reflect.New(fi.field.Type).Elem()
+		if err := setFieldValue(value, e.value); err != nil {
+			message := fmt.Sprintf("invalid value for %s: %v", e.key, err)
+			if sensitive {
+				message = fmt.Sprintf("invalid value for %s", e.key)
+			}
+			issues = append(issues, LintIssue{File: path, Line: e.line, Key: e.key, Kind: LintInvalidValue, Message: message})
+			continue
+		}
+
+		if !options.Validate {
+			continue
+		}
+		constrained := fi
+		constrained.name = e.key
+		if err := checkConstraints(constrained, value, sensitive); err != nil {
+			issues = append(issues, LintIssue{File: path, Line: e.line, Key: e.key, Kind: LintInvalidValue, Message: err.Error()})
+		}
+	}
+	return issues
+}
+
+// isValidEnvKey indica se key é um nome de variável aceito: letras, dígitos, _ e .,
+// sem começar com dígito.
+func isValidEnvKey(key string) bool {
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		return false
+	}
+	for _, r := range key {
+		if !(r == '_' || r == '.' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// envClosingQuote retorna o índice da aspa que fecha um valor de arquivo .env, ou -1.
+// Como no godotenv, uma aspa precedida de \ não fecha o valor, com aspas duplas ou
+// simples (com aspas simples, o \ é mantido no valor).
+func envClosingQuote(body string, quote byte) int {
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\':
+			i++
+		case body[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeDoubleQuoted expande os escapes aceitos em valores com aspas duplas.
+func unescapeDoubleQuoted(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+}
+
+// inlineComment retorna o índice de um comentário (# precedido de espaço) em um
+// valor sem aspas, ou -1.
+func inlineComment(value string) int {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return i
+		}
+	}
+	return -1
+}
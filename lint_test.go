@@ -0,0 +1,155 @@
+package configloader
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// LintConfig struct para testes de LintEnvFile
+type LintConfig struct {
+	Host     string        `env:"DB_HOST,localhost"`
+	Port     int           `env:"DB_PORT,5432" max:"65535"`
+	Timeout  time.Duration `env:"DB_TIMEOUT,5s"`
+	Password SecretString  `env:"DB_PASSWORD"`
+	PIN      Secret[int]   `env:"DB_PIN"`
+	Mode     string        `env:"MODE,dev" enum:"dev,prod"`
+}
+
+// TestLintEnvFile testa cada tipo de problema com a linha correspondente
+func TestLintEnvFile(t *testing.T) {
+	content := `# banco
+DB_HOST=db.local
+DB_PORT=abc
+DB_HSOT=typo
+export DB_TIMEOUT=5 seconds
+DB_PIN=12x4
+MODE=staging   
+not a pair
+1BAD=x
+DB_HOST="db2.local"
+DB_PASSWORD="multi
+line"
+MODE='dev' extra
+DB_PORT=70000 # comentário
+`
+	path := writeEnvFile(t, "lint.env", content)
+
+	issues, err := LintEnvFile(path, &LintConfig{}, WithValidation())
+	if err != nil {
+		t.Fatalf("LintEnvFile failed: %v", err)
+	}
+
+	expected := []struct {
+		line int
+		kind LintKind
+		text string
+	}{
+		{3, LintInvalidValue, "invalid value for DB_PORT: invalid integer value 'abc'"},
+		{4, LintUnused, "DB_HSOT is not used by the config (did you mean DB_HOST?)"},
+		{5, LintInvalidValue, "invalid value for DB_TIMEOUT: invalid duration value '5 seconds'"},
+		{6, LintInvalidValue, "invalid value for DB_PIN"},
+		{7, LintTrailingSpace, "unquoted value for MODE has trailing whitespace"},
+		{7, LintInvalidValue, "MODE must be one of [dev, prod]"},
+		{8, LintMalformed, `expected KEY=VALUE, got "not a pair"`},
+		{9, LintMalformed, `invalid key "1BAD"`},
+		{10, LintDuplicate, "duplicate key DB_HOST (first defined at line 2)"},
+		{13, LintMalformed, "unexpected text after quoted value for MODE"},
+		{14, LintDuplicate, "duplicate key DB_PORT (first defined at line 3)"},
+		{14, LintInvalidValue, "DB_PORT must be at most 65535"},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d:\n%v", len(expected), len(issues), issues)
+	}
+	for i, want := range expected {
+		got := issues[i]
+		if got.Line != want.line || got.Kind != want.kind || !strings.Contains(got.Message, want.text) {
+			t.Errorf("Issue %d: Expected line %d %s %q, got %s (%s)", i, want.line, want.kind, want.text, got, got.Kind)
+		}
+	}
+
+	if s := issues[0].String(); s != path+":3: invalid value for DB_PORT: invalid integer value 'abc': strconv.ParseInt: parsing \"abc\": invalid syntax" {
+		t.Errorf("Unexpected issue format: %s", s)
+	}
+}
+
+// TestLintEnvFile_WithoutConfig testa que, sem struct, apenas a sintaxe é verificada
+func TestLintEnvFile_WithoutConfig(t *testing.T) {
+	path := writeEnvFile(t, "syntax.env", "A=1\nB=\"unterminated\nC=3\n")
+
+	issues, err := LintEnvFile(path, nil)
+	if err != nil {
+		t.Fatalf("LintEnvFile failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Kind != LintMalformed || issues[0].Line != 2 {
+		t.Errorf("Expected one malformed issue at line 2, got %v", issues)
+	}
+}
+
+// TestLintEnvFile_Prefix testa a comparação de nomes com prefixo e valores com expansão
+func TestLintEnvFile_Prefix(t *testing.T) {
+	path := writeEnvFile(t, "prefix.env", "APP_DB_HOST=x\nAPP_DB_PORT=${PORT}\nDB_MODE=prod\n")
+
+	issues, err := LintEnvFile(path, LintConfig{}, WithPrefix("APP_"))
+	if err != nil {
+		t.Fatalf("LintEnvFile failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Line != 3 || issues[0].Kind != LintUnused {
+		t.Errorf("Expected only DB_MODE unused, got %v", issues)
+	}
+}
+
+// TestLintEnvFile_Errors testa arquivo inexistente e config inválida
+func TestLintEnvFile_Errors(t *testing.T) {
+	if _, err := LintEnvFile("missing.env", nil); err == nil || !strings.Contains(err.Error(), "error reading .env file") {
+		t.Errorf("Expected read error, got %v", err)
+	}
+
+	path := writeEnvFile(t, "ok.env", "A=1\n")
+	if _, err := LintEnvFile(path, "not a struct"); err == nil {
+		t.Errorf("Expected error for non-struct config")
+	}
+}
+
+// TestLintEnvFile_CleanFile testa que um arquivo válido não gera problemas
+func TestLintEnvFile_CleanFile(t *testing.T) {
+	path := writeEnvFile(t, "clean.env", "# ok\nDB_HOST=db.local # comentário\nDB_PORT=6543\nMODE: \"prod\"\nDB_PASSWORD='p#ss word '\n")
+
+	issues, err := LintEnvFile(path, &LintConfig{})
+	if err != nil {
+		t.Fatalf("LintEnvFile failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+// TestParseEnvEntries_SingleQuoted testa valores com aspas simples em várias linhas,
+// sem processamento de escapes, comparando com o godotenv
+func TestParseEnvEntries_SingleQuoted(t *testing.T) {
+	content := "KEY='line1\nline2 \\n # x'\nESCAPED='a\\'b'\nNEXT=1\n"
+
+	entries, issues := parseEnvEntries("single.env", content)
+	if len(issues) != 0 {
+		t.Fatalf("Expected no issues, got %v", issues)
+	}
+
+	expected, err := godotenv.Unmarshal(content)
+	if err != nil {
+		t.Fatalf("godotenv.Unmarshal failed: %v", err)
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %v", len(expected), entries)
+	}
+	for _, e := range entries {
+		if e.value != expected[e.key] {
+			t.Errorf("Expected %s=%q, got %q", e.key, expected[e.key], e.value)
+		}
+	}
+	if entries[2].key != "NEXT" || entries[2].line != 4 {
+		t.Errorf("Expected NEXT at line 4, got %+v", entries[2])
+	}
+}
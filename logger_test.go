@@ -0,0 +1,127 @@
+package configloader
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestLogger cria um logger em formato texto que grava em buf
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// TestLoad_Logger testa os logs estruturados de arquivos e valores
+func TestLoad_Logger(t *testing.T) {
+	type LoggerConfig struct {
+		Host     string `env:"LOG_HOST,localhost"`
+		Password string `env:"LOG_PASSWORD"`
+	}
+
+	path := writeEnvFile(t, ".env", "LOG_PASSWORD=s3cr3t\n", "LOG_PASSWORD")
+
+	var buf bytes.Buffer
+	var cfg LoggerConfig
+	err := Load(&cfg, LoadOptions{EnvFiles: []string{path}, UseSystem: true, Logger: newTestLogger(&buf)})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, `msg="loaded .env file" path=`+path) {
+		t.Errorf("Expected loaded file log, got:\n%s", output)
+	}
+	if !strings.Contains(output, `name=LOG_HOST source=default value=localhost`) {
+		t.Errorf("Expected resolved value log, got:\n%s", output)
+	}
+	if strings.Contains(output, "s3cr3t") {
+		t.Errorf("Sensitive value leaked in log:\n%s", output)
+	}
+}
+
+// TestLoad_LoggerDeprecated testa o uso de nomes obsoletos pela tag `deprecated`
+func TestLoad_LoggerDeprecated(t *testing.T) {
+	type DeprecatedConfig struct {
+		Host string `env:"NEW_HOST,localhost" deprecated:"OLD_HOST, LEGACY_HOST"`
+	}
+
+	t.Setenv("LEGACY_HOST", "legacy.db")
+
+	var buf bytes.Buffer
+	var prov Provenance
+	var cfg DeprecatedConfig
+	err := Load(&cfg, WithLogger(newTestLogger(&buf)), WithProvenance(&prov), WithStrict(StrictError))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "legacy.db" {
+		t.Errorf("Expected Host from deprecated name, got '%s'", cfg.Host)
+	}
+	if !strings.Contains(buf.String(), `level=WARN msg="deprecated config name used" name=LEGACY_HOST replacement=NEW_HOST`) {
+		t.Errorf("Expected deprecated name log, got:\n%s", buf.String())
+	}
+	if origin, ok := prov.Lookup("NEW_HOST"); !ok || origin.Kind != OriginSystem {
+		t.Errorf("Expected system origin for NEW_HOST, got %v", origin)
+	}
+
+	// O nome atual tem precedência e não gera aviso
+	t.Setenv("NEW_HOST", "new.db")
+	buf.Reset()
+	if err := Load(&cfg, WithLogger(newTestLogger(&buf))); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "new.db" || strings.Contains(buf.String(), "deprecated") {
+		t.Errorf("Expected current name without warning, got '%s':\n%s", cfg.Host, buf.String())
+	}
+}
+
+// TestLoad_LoggerMissingFile testa o log de arquivo ausente
+func TestLoad_LoggerMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.env")
+
+	var buf bytes.Buffer
+	var cfg TestConfigWithoutTags
+	err := Load(&cfg, LoadOptions{EnvFiles: []string{missing}, UseSystem: true, Logger: newTestLogger(&buf)})
+	if err == nil {
+		t.Fatal("Expected error for missing file, got nil")
+	}
+
+	if !strings.Contains(buf.String(), `level=WARN msg="failed to load .env file" path=`+missing) {
+		t.Errorf("Expected missing file log, got:\n%s", buf.String())
+	}
+}
+
+// TestReload_Logger testa os logs de resultado do reload
+func TestReload_Logger(t *testing.T) {
+	var cfg ReloadConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	os.Setenv("RELOAD_LOG_LEVEL", "debug")
+	defer os.Unsetenv("RELOAD_LOG_LEVEL")
+
+	var buf bytes.Buffer
+	if err := Reload(&cfg, LoadOptions{UseSystem: true, Logger: newTestLogger(&buf)}); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `msg="config reloaded" changes=1 changed.RELOAD_LOG_LEVEL.old=info changed.RELOAD_LOG_LEVEL.new=debug`) {
+		t.Errorf("Expected reload log, got:\n%s", buf.String())
+	}
+
+	os.Setenv("RELOAD_PORT", "9090")
+	defer os.Unsetenv("RELOAD_PORT")
+
+	buf.Reset()
+	if err := Reload(&cfg, LoadOptions{UseSystem: true, Logger: newTestLogger(&buf)}); err == nil {
+		t.Fatal("Expected restart required error, got nil")
+	}
+
+	if !strings.Contains(buf.String(), `level=WARN msg="config reload rejected: restart required" fields=[RELOAD_PORT]`) {
+		t.Errorf("Expected restart required log, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,149 @@
+package configloader
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Option configura uma carga feita por Load, LoadAs, FindAndLoad ou Reload.
+//
+// As funções With* partem dos padrões seguros (variáveis do sistema habilitadas)
+// e alteram apenas o que informam, então novas opções podem ser adicionadas sem
+// mudar o comportamento de quem não as usa:
+//
+//	err := Load(&cfg,
+//	    WithFiles("./config/.env"),
+//	    WithPrefix("APP_"),
+//	    WithLogger(slog.Default()),
+//	)
+//
+// LoadOptions também implementa Option, para compatibilidade: uma LoadOptions
+// substitui todas as opções anteriores, inclusive os padrões. Atenção: o valor
+// zero de LoadOptions tem UseSystem false.
+type Option interface {
+	applyOption(options *LoadOptions)
+}
+
+// applyOption substitui todas as opções por o.
+func (o LoadOptions) applyOption(options *LoadOptions) {
+	*options = o
+}
+
+// optionFunc adapta uma função ao tipo Option.
+type optionFunc func(options *LoadOptions)
+
+func (f optionFunc) applyOption(options *LoadOptions) {
+	f(options)
+}
+
+// WithFiles acrescenta arquivos .env a serem carregados, na ordem informada.
+func WithFiles(files ...string) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.EnvFiles = append(append([]string{}, o.EnvFiles...), files...)
+	})
+}
+
+// WithConfigFile acrescenta arquivos de configuração estruturados (ver LoadOptions.ConfigFiles).
+func WithConfigFile(files ...string) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.ConfigFiles = append(append([]string{}, o.ConfigFiles...), files...)
+	})
+}
+
+// WithProfile define o perfil de ambiente usado para carregar os arquivos .env em
+// camadas (ver LoadOptions.Profile).
+func WithProfile(profile string) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Profile = profile
+	})
+}
+
+// WithDiscovery procura os arquivos .env conforme opts quando nenhum arquivo é
+// informado com WithFiles (ver DiscoveryOptions).
+func WithDiscovery(opts DiscoveryOptions) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Discovery = &opts
+	})
+}
+
+// WithLoadedFiles registra em files os caminhos dos arquivos .env carregados.
+func WithLoadedFiles(files *[]string) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.LoadedFiles = files
+	})
+}
+
+// WithFlags usa os flags registrados com RegisterFlags, com precedência sobre todas
+// as outras fontes (ver FlagSource).
+func WithFlags(flags *FlagSource) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Flags = flags
+	})
+}
+
+// WithStrict define como variáveis desconhecidas são tratadas (ver StrictMode).
+func WithStrict(mode StrictMode) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Strict = mode
+	})
+}
+
+// WithPrefix define um prefixo para todas as variáveis: com WithPrefix("APP_"),
+// o campo com tag `env:"DB_HOST"` é lido de APP_DB_HOST.
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Prefix = prefix
+	})
+}
+
+// WithSource acrescenta uma Source, consultada quando a variável não está definida
+// no ambiente (nem nos arquivos .env). Sources são consultadas na ordem informada.
+func WithSource(src Source) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Sources = append(append([]Source{}, o.Sources...), src)
+	})
+}
+
+// WithoutSystemEnv desabilita a leitura do ambiente do processo (inclusive dos valores
+// carregados de arquivos .env); apenas Sources e defaults são usados.
+func WithoutSystemEnv() Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.UseSystem = false
+	})
+}
+
+// WithLogger define o logger estruturado (ver LoadOptions.Logger).
+func WithLogger(logger *slog.Logger) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Logger = logger
+	})
+}
+
+// WithTrace define o destino do log de resolução (ver LoadOptions.Trace).
+func WithTrace(w io.Writer) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Trace = w
+	})
+}
+
+// WithProvenance registra em p a origem de cada campo (ver LoadOptions.Provenance).
+func WithProvenance(p *Provenance) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Provenance = p
+	})
+}
+
+// WithValidation faz Load rejeitar valores que violam as tags `enum`, `min` e `max`
+// (ver LoadOptions.Validate).
+func WithValidation() Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Validate = true
+	})
+}
+
+// WithMask define o mascaramento usado em Trace, Logger e Reload (ver LoadOptions.Mask).
+func WithMask(mask MaskOptions) Option {
+	return optionFunc(func(o *LoadOptions) {
+		o.Mask = mask
+	})
+}
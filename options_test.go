@@ -0,0 +1,126 @@
+package configloader
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// OptionsConfig struct para testes das opções funcionais
+type OptionsConfig struct {
+	Host string `env:"OPT_HOST,localhost"`
+	Port int    `env:"OPT_PORT,8080" max:"9999"`
+	Mode string `env:"OPT_MODE"`
+}
+
+// TestWithFiles_KeepsSystemEnv testa que WithFiles não desabilita as variáveis do sistema,
+// ao contrário de LoadOptions{EnvFiles: ...}
+func TestWithFiles_KeepsSystemEnv(t *testing.T) {
+	t.Setenv("OPT_MODE", "system")
+	path := writeEnvFile(t, "options.env", "OPT_HOST=file.local\n", "OPT_HOST")
+
+	var cfg OptionsConfig
+	if err := Load(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Mode != "system" {
+		t.Errorf("Expected Mode 'system', got '%s'", cfg.Mode)
+	}
+	if cfg.Host != "file.local" {
+		t.Errorf("Expected Host 'file.local', got '%s'", cfg.Host)
+	}
+
+	var legacy OptionsConfig
+	if err := Load(&legacy, LoadOptions{EnvFiles: []string{path}}); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if legacy.Mode != "" {
+		t.Errorf("Expected LoadOptions zero value to disable system env, got Mode '%s'", legacy.Mode)
+	}
+}
+
+// TestWithPrefix testa a leitura de variáveis com prefixo e as mensagens de erro
+func TestWithPrefix(t *testing.T) {
+	t.Setenv("APP_OPT_HOST", "prefixed.local")
+	t.Setenv("OPT_HOST", "unprefixed.local")
+
+	var cfg OptionsConfig
+	if err := Load(&cfg, WithPrefix("APP_")); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "prefixed.local" {
+		t.Errorf("Expected Host 'prefixed.local', got '%s'", cfg.Host)
+	}
+
+	t.Setenv("APP_OPT_PORT", "10000")
+	err := Load(&cfg, WithPrefix("APP_"), WithValidation())
+	if err == nil || !strings.Contains(err.Error(), "APP_OPT_PORT must be at most 9999") {
+		t.Errorf("Expected prefixed constraint error, got %v", err)
+	}
+}
+
+// TestWithSource testa a precedência entre sistema, Sources e defaults
+func TestWithSource(t *testing.T) {
+	t.Setenv("OPT_HOST", "system.local")
+
+	first := MapSource{"OPT_HOST": "first.local", "OPT_MODE": "first"}
+	second := MapSource{"OPT_MODE": "second", "OPT_PORT": "9000"}
+
+	var prov Provenance
+	var cfg OptionsConfig
+	if err := Load(&cfg, WithSource(first), WithSource(second), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Host != "system.local" {
+		t.Errorf("Expected system env to win, got Host '%s'", cfg.Host)
+	}
+	if cfg.Mode != "first" {
+		t.Errorf("Expected first source to win, got Mode '%s'", cfg.Mode)
+	}
+	if cfg.Port != 9000 {
+		t.Errorf("Expected Port 9000 from second source, got %d", cfg.Port)
+	}
+
+	origin, _ := prov.Lookup("OPT_MODE")
+	if origin.Kind != OriginSource || origin.String() != "configloader.MapSource" {
+		t.Errorf("Expected source origin, got %s (%s)", origin.Kind, origin)
+	}
+}
+
+// TestWithoutSystemEnv testa que apenas Sources e defaults são usados
+func TestWithoutSystemEnv(t *testing.T) {
+	t.Setenv("OPT_HOST", "system.local")
+
+	var trace bytes.Buffer
+	cfg, err := LoadAs[OptionsConfig](WithoutSystemEnv(), WithSource(MapSource{"OPT_MODE": "src"}), WithTrace(&trace))
+	if err != nil {
+		t.Fatalf("LoadAs failed: %v", err)
+	}
+	if cfg.Host != "localhost" {
+		t.Errorf("Expected default Host, got '%s'", cfg.Host)
+	}
+	if cfg.Mode != "src" {
+		t.Errorf("Expected Mode 'src', got '%s'", cfg.Mode)
+	}
+	if !strings.Contains(trace.String(), "OPT_MODE: found in source configloader.MapSource") {
+		t.Errorf("Expected trace of source lookup, got:\n%s", trace.String())
+	}
+}
+
+// TestOptions_LoadOptionsReplaces testa que LoadOptions substitui as opções anteriores
+// e que opções funcionais posteriores a alteram
+func TestOptions_LoadOptionsReplaces(t *testing.T) {
+	options := resolveOptions([]Option{WithPrefix("APP_"), LoadOptions{UseSystem: true}, WithFiles("a.env")})
+	if options.Prefix != "" {
+		t.Errorf("Expected LoadOptions to reset Prefix, got '%s'", options.Prefix)
+	}
+	if !options.UseSystem || len(options.EnvFiles) != 1 {
+		t.Errorf("Expected UseSystem with one file, got %+v", options)
+	}
+
+	defaults := resolveOptions(nil)
+	if !defaults.UseSystem {
+		t.Errorf("Expected UseSystem true by default")
+	}
+}
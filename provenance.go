@@ -0,0 +1,350 @@
+package configloader
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/joho/godotenv"
+)
+
+// OriginKind identifica o tipo de fonte que definiu o valor de um campo.
+type OriginKind string
+
+const (
+	// OriginFlag indica que o valor veio de um flag de linha de comando (ver RegisterFlags).
+	OriginFlag OriginKind = "flag"
+
+	// OriginSystem indica que o valor veio de uma variável de ambiente do sistema.
+	OriginSystem OriginKind = "system"
+
+	// OriginFile indica que o valor veio de um arquivo .env ou de configuração (FileSource).
+	OriginFile OriginKind = "file"
+
+	// OriginSource indica que o valor veio de uma Source (ex: LoadFromSource).
+	OriginSource OriginKind = "source"
+
+	// OriginDefault indica que o valor veio do default da tag `env`.
+	OriginDefault OriginKind = "default"
+
+	// OriginUnset indica que nenhuma fonte definiu o valor e não há default.
+	OriginUnset OriginKind = "unset"
+)
+
+// Origin descreve de onde veio o valor de um campo carregado.
+type Origin struct {
+	// Name é o nome da variável de ambiente.
+	Name string
+
+	// Field é o caminho do campo na struct, ex: "Port" ou "Database.Host".
+	Field string
+
+	// Kind é o tipo de fonte que definiu o valor.
+	Kind OriginKind
+
+	// File e Line identificam o arquivo e a linha, quando Kind é OriginFile.
+	// Quando Kind é OriginSource, File é o nome da Source; quando é OriginFlag, o flag (ex: "--db-host").
+	File string
+	Line int
+
+	// Raw é o valor string antes da conversão para o tipo do campo.
+	// Atenção: não é mascarado, mesmo para campos sensíveis.
+	Raw string
+}
+
+// String retorna uma descrição curta da origem, como "system", ".env:3", "default",
+// "flag --db-host" ou o nome da Source.
+func (o Origin) String() string {
+	switch {
+	case o.Kind == OriginFile:
+		return fmt.Sprintf("%s:%d", o.File, o.Line)
+	case o.Kind == OriginFlag:
+		return fmt.Sprintf("flag %s", o.File)
+	case o.Kind == OriginSource && o.File != "":
+		return o.File
+	}
+	return string(o.Kind)
+}
+
+// Provenance lista a origem de cada campo com tag `env`, na ordem da struct.
+// É preenchida por Load quando LoadOptions.Provenance é informado.
+//
+// Exemplo:
+//
+//	var prov Provenance
+//	err := Load(&cfg, LoadOptions{UseSystem: true, Provenance: &prov})
+//	origin, _ := prov.Lookup("DB_HOST")
+//	fmt.Println(origin) // config/.env:12
+type Provenance []Origin
+
+// Lookup retorna a origem da variável de ambiente informada.
+func (p Provenance) Lookup(name string) (Origin, bool) {
+	for _, origin := range p {
+		if origin.Name == name {
+			return origin, true
+		}
+	}
+	return Origin{}, false
+}
+
+// lookupField retorna a origem do campo pelo caminho na struct, ex: "Database.Host".
+// Ao contrário de Lookup, independe do prefixo usado na carga.
+func (p Provenance) lookupField(path string) (Origin, bool) {
+	for _, origin := range p {
+		if origin.Field == path {
+			return origin, true
+		}
+	}
+	return Origin{}, false
+}
+
+// SPrintProvenance funciona como SPrint, mas acrescenta a origem de cada valor.
+// Campos sensíveis continuam mascarados. Se a carga usou um prefixo, os nomes
+// exibidos incluem o prefixo.
+//
+// Parâmetros:
+//   - config: Struct com as configurações carregadas
+//   - prov: Provenance preenchida pelo Load da mesma struct
+//   - opts: Opções de mascaramento (opcional)
+//
+// Retorna:
+//   - string: Configurações formatadas com a origem de cada valor
+//
+// Exemplo:
+//
+//	fmt.Println(SPrintProvenance(cfg, prov))
+//	// Output:
+//	// Environment Configuration:
+//	// ==========================
+//	// SERVER_PORT         : 8080 (default)
+//	// DB_HOST             : db.internal (config/.env:3)
+//	// DB_PASSWORD         : ***MASKED*** (system)
+func SPrintProvenance(config any, prov Provenance, opts ...MaskOptions) string {
+	mask := resolveMaskOptions(opts)
+	var result strings.Builder
+	v := reflect.ValueOf(config)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	result.WriteString("Environment Configuration:\n")
+	result.WriteString("==========================\n")
+
+	for _, fi := range structFields(v.Type()) {
+		name, source := fi.name, "unknown"
+		if origin, ok := prov.lookupField(fi.path()); ok {
+			name, source = origin.Name, origin.String()
+		}
+
+		result.WriteString(fmt.Sprintf("%-20s: %s (%s)\n", name, mask.displayValue(fi, v.FieldByIndex(fi.index)), source))
+	}
+
+	return result.String()
+}
+
+// fileOrigin registra o arquivo, a linha e o valor que um arquivo .env definiu.
+type fileOrigin struct {
+	file  string
+	line  int
+	value string
+}
+
+// fileOrigins guarda as variáveis que o loader definiu no processo a partir de arquivos
+// .env, para distinguir esses valores das variáveis do sistema em cargas posteriores.
+// Uma variável sai do mapa quando o loader a remove ou quando observa que ela foi
+// alterada ou removida por outro código (ver envFileOwner); a partir daí ela é tratada
+// como variável do sistema, mesmo que volte a ter o valor do arquivo.
+var fileOrigins sync.Map // map[string]fileOrigin
+
+// envLayer é um arquivo .env em uma pilha de camadas. Camadas opcionais que não
+// existem ou não podem ser lidas são ignoradas.
+type envLayer struct {
+	path     string
+	optional bool
+}
+
+// loadEnvFiles carrega os arquivos .env informados (todos obrigatórios) como camadas,
+// com o último arquivo tendo precedência. Sem argumentos, tenta carregar ".env" do
+// diretório atual.
+func (l *loader) loadEnvFiles(files ...string) error {
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	layers := make([]envLayer, len(files))
+	for i, file := range files {
+		layers[i] = envLayer{path: file}
+	}
+
+	_, err := l.loadEnvLayers(layers)
+	return err
+}
+
+// loadEnvLayers carrega as camadas no ambiente do processo, na ordem: quando uma chave
+// aparece em mais de um arquivo, vale a do último. Variáveis reais do sistema nunca são
+// sobrescritas; valores definidos por cargas anteriores de arquivos .env são (por
+// exemplo, em Reload após editar o arquivo), e variáveis definidas antes por uma das
+// camadas que não aparecem mais em nenhuma delas são removidas. Registra de qual
+// arquivo e linha veio cada variável definida e retorna os caminhos efetivamente
+// carregados.
+func (l *loader) loadEnvLayers(layers []envLayer) ([]string, error) {
+	merged := make(map[string]fileOrigin)
+	var order []string
+	var loaded []string
+
+	for _, layer := range layers {
+		values, err := godotenv.Read(layer.path)
+		if err != nil {
+			if layer.optional {
+				l.tracef("skipping %s: %v", layer.path, err)
+				l.logger.Debug("skipped .env file", "path", layer.path, "error", err)
+				continue
+			}
+			l.logger.Warn("failed to load .env file", "path", layer.path, "error", err)
+			return loaded, err
+		}
+		lines := envFileLines(layer.path)
+
+		for key, value := range values {
+			if _, ok := merged[key]; !ok {
+				order = append(order, key)
+			}
+			merged[key] = fileOrigin{file: layer.path, line: lines[key], value: value}
+		}
+		loaded = append(loaded, layer.path)
+		l.tracef("read %s (%d keys)", layer.path, len(values))
+	}
+
+	if l.fileKeys == nil {
+		l.fileKeys = make(map[string]fileOrigin)
+	}
+	for key, fo := range merged {
+		l.fileKeys[key] = fo
+	}
+
+	l.unsetStaleKeys(layers, merged)
+
+	applied := make(map[string]int)
+	for _, key := range order {
+		fo := merged[key]
+		if _, ok := os.LookupEnv(key); ok {
+			if _, owned := envFileOwner(key); !owned {
+				continue
+			}
+		}
+		os.Setenv(key, fo.value)
+		fileOrigins.Store(key, fo)
+		applied[fo.file]++
+	}
+
+	for _, path := range loaded {
+		l.logger.Info("loaded .env file", "path", path, "applied", applied[path])
+	}
+
+	if len(loaded) == 0 {
+		return nil, fmt.Errorf("no .env file found")
+	}
+	return loaded, nil
+}
+
+// unsetStaleKeys remove do ambiente as variáveis que uma das camadas definiu em uma
+// carga anterior e que não aparecem mais em nenhuma delas (ex: a linha foi apagada do
+// arquivo antes de Reload). Variáveis de outros arquivos não são afetadas.
+func (l *loader) unsetStaleKeys(layers []envLayer, merged map[string]fileOrigin) {
+	paths := make(map[string]bool, len(layers))
+	for _, layer := range layers {
+		paths[layer.path] = true
+	}
+
+	fileOrigins.Range(func(k, v any) bool {
+		key, fo := k.(string), v.(fileOrigin)
+		if _, ok := merged[key]; ok || !paths[fo.file] {
+			return true
+		}
+		if _, owned := envFileOwner(key); owned {
+			os.Unsetenv(key)
+			fileOrigins.Delete(key)
+			l.tracef("unset %s: no longer defined in %s", key, fo.file)
+		}
+		return true
+	})
+}
+
+// envFileOwner retorna o arquivo .env que definiu a variável, se ela ainda pertence ao
+// loader: foi definida por ele e não foi alterada nem removida desde então. Caso
+// contrário, a posse é descartada e a variável passa a ser tratada como do sistema.
+func envFileOwner(key string) (fileOrigin, bool) {
+	stored, ok := fileOrigins.Load(key)
+	if !ok {
+		return fileOrigin{}, false
+	}
+
+	fo := stored.(fileOrigin)
+	if current, set := os.LookupEnv(key); !set || current != fo.value {
+		fileOrigins.Delete(key)
+		return fileOrigin{}, false
+	}
+	return fo, true
+}
+
+// profileLayers retorna as camadas para os arquivos base e o perfil informados, na
+// ordem de precedência crescente: base, base.<perfil>, base.local e base.<perfil>.local.
+// Sem perfil, apenas os arquivos base. As variantes são sempre opcionais.
+func profileLayers(bases []string, profile string, optional bool) []envLayer {
+	var layers []envLayer
+	for _, base := range bases {
+		layers = append(layers, envLayer{path: base, optional: optional})
+		if profile == "" {
+			continue
+		}
+		for _, suffix := range []string{"." + profile, ".local", "." + profile + ".local"} {
+			layers = append(layers, envLayer{path: base + suffix, optional: true})
+		}
+	}
+	return layers
+}
+
+// envOrigin determina a origem de um valor lido do ambiente do processo.
+// Se a variável pertence a um arquivo .env (ver envFileOwner), a origem é o arquivo.
+func envOrigin(envName, fieldName, value string) Origin {
+	origin := Origin{Name: envName, Field: fieldName, Kind: OriginSystem, Raw: value}
+	if fo, ok := envFileOwner(envName); ok {
+		origin.Kind = OriginFile
+		origin.File = fo.file
+		origin.Line = fo.line
+	}
+	return origin
+}
+
+// envFileLines retorna a linha (a partir de 1) em que cada chave é definida no arquivo .env.
+// Se a chave aparece mais de uma vez, vale a última ocorrência, como no godotenv.
+func envFileLines(path string) map[string]int {
+	lines := make(map[string]int)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return lines
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		idx := strings.IndexAny(line, "=:")
+		if idx <= 0 {
+			continue
+		}
+
+		lines[strings.TrimSpace(line[:idx])] = n
+	}
+
+	return lines
+}
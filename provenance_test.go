@@ -0,0 +1,115 @@
+package configloader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeEnvFile cria um arquivo .env temporário e remove as variáveis carregadas ao final do teste
+func writeEnvFile(t *testing.T, name, content string, keys ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+
+	t.Cleanup(func() {
+		for _, key := range keys {
+			os.Unsetenv(key)
+		}
+	})
+
+	return path
+}
+
+// TestLoad_Provenance testa o registro da origem de cada campo
+func TestLoad_Provenance(t *testing.T) {
+	type ProvenanceConfig struct {
+		Host     string `env:"PROV_HOST,localhost"`
+		Port     string `env:"PROV_PORT,8080"`
+		Password string `env:"PROV_PASSWORD"`
+		Missing  string `env:"PROV_MISSING"`
+	}
+
+	path := writeEnvFile(t, ".env", "# comentário\nPROV_HOST=db.internal\n\nPROV_PASSWORD=from_file\n", "PROV_HOST", "PROV_PASSWORD")
+
+	os.Setenv("PROV_PASSWORD", "from_system")
+	defer os.Unsetenv("PROV_PASSWORD")
+
+	var cfg ProvenanceConfig
+	var prov Provenance
+	err := Load(&cfg, LoadOptions{EnvFiles: []string{path}, UseSystem: true, Provenance: &prov})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected Origin
+	}{
+		{"PROV_HOST", Origin{Name: "PROV_HOST", Field: "Host", Kind: OriginFile, File: path, Line: 2, Raw: "db.internal"}},
+		{"PROV_PORT", Origin{Name: "PROV_PORT", Field: "Port", Kind: OriginDefault, Raw: "8080"}},
+		{"PROV_PASSWORD", Origin{Name: "PROV_PASSWORD", Field: "Password", Kind: OriginSystem, Raw: "from_system"}},
+		{"PROV_MISSING", Origin{Name: "PROV_MISSING", Field: "Missing", Kind: OriginUnset}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, ok := prov.Lookup(tt.name)
+			if !ok {
+				t.Fatalf("Expected provenance for %s", tt.name)
+			}
+			if origin != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, origin)
+			}
+		})
+	}
+
+	// Uma nova carga continua atribuindo o valor ao arquivo
+	if err := Load(&cfg, LoadOptions{UseSystem: true, Provenance: &prov}); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if origin, _ := prov.Lookup("PROV_HOST"); origin.Kind != OriginFile {
+		t.Errorf("Expected PROV_HOST from file on second load, got %s", origin)
+	}
+	if len(prov) != 4 {
+		t.Errorf("Expected provenance to be reset between loads, got %d entries", len(prov))
+	}
+
+	result := SPrintProvenance(cfg, prov)
+	if !strings.Contains(result, "db.internal ("+path+":2)") {
+		t.Errorf("Expected file origin in output, got:\n%s", result)
+	}
+	if !strings.Contains(result, maskedValue+" (system)") {
+		t.Errorf("Expected masked system origin in output, got:\n%s", result)
+	}
+}
+
+// TestSPrintProvenance_Prefix testa a exibição da origem quando a carga usa um prefixo
+func TestSPrintProvenance_Prefix(t *testing.T) {
+	type PrefixedConfig struct {
+		Host string `env:"PROV_HOST,localhost"`
+		Port int    `env:"PROV_PORT,8080"`
+	}
+	t.Setenv("APP_PROV_HOST", "db.internal")
+
+	var prov Provenance
+	var cfg PrefixedConfig
+	if err := Load(&cfg, WithPrefix("APP_"), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	result := SPrintProvenance(cfg, prov)
+	if !strings.Contains(result, "APP_PROV_HOST       : db.internal (system)") {
+		t.Errorf("Expected prefixed system origin, got:\n%s", result)
+	}
+	if !strings.Contains(result, "APP_PROV_PORT       : 8080 (default)") {
+		t.Errorf("Expected prefixed default origin, got:\n%s", result)
+	}
+	if strings.Contains(result, "unknown") {
+		t.Errorf("Expected no unknown origins, got:\n%s", result)
+	}
+}
@@ -0,0 +1,118 @@
+package configloader
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// RestartRequiredError é retornado por Reload quando um ou mais campos marcados
+// com `reload:"false"` tiveram seus valores alterados.
+// Esses campos (porta de escuta, DSN do banco, etc.) só são lidos na inicialização
+// do serviço, então a mudança só terá efeito após um restart.
+type RestartRequiredError struct {
+	// Fields contém os nomes das variáveis de ambiente que mudaram, com o
+	// LoadOptions.Prefix, se houver.
+	Fields []string
+}
+
+func (e *RestartRequiredError) Error() string {
+	return fmt.Sprintf("restart required: non-reloadable fields changed: %s", strings.Join(e.Fields, ", "))
+}
+
+// Reload recarrega as configurações em uma struct já carregada anteriormente.
+// Os valores são resolvidos com as mesmas regras de Load em uma cópia da struct,
+// e só são aplicados se a carga for bem-sucedida.
+//
+// Campos marcados com `reload:"false"` não podem mudar em tempo de execução.
+// Se algum deles tiver valor diferente do atual, o reload é rejeitado por completo
+// (a struct permanece inalterada) e um *RestartRequiredError é retornado com a
+// lista de variáveis alteradas.
+//
+// Parâmetros:
+//   - config: Ponteiro para a struct carregada anteriormente
+//   - opts: Opções de carregamento (opcional), as mesmas aceitas por Load
+//
+// Exemplo:
+//
+//	type Config struct {
+//	    Port     string `env:"PORT,8080" reload:"false"`
+//	    LogLevel string `env:"LOG_LEVEL,info"`
+//	}
+//
+//	err := Reload(&cfg)
+//	var restartErr *RestartRequiredError
+//	if errors.As(err, &restartErr) {
+//	    log.Printf("restart required: %v", restartErr.Fields)
+//	}
+//
+// Se LoadOptions.Logger for informado, o resultado do reload é logado, incluindo
+// as variáveis alteradas (com valores sensíveis mascarados, como em Diff).
+//
+// Retorna:
+//   - error: Erro de carregamento/validação ou *RestartRequiredError
+func Reload(config any, opts ...Option) error {
+	v := reflect.ValueOf(config)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to a struct")
+	}
+
+	current := v.Elem()
+	t := current.Type()
+
+	// Carrega em uma cópia para não aplicar nada se houver erro.
+	// Campos com tag são zerados para que variáveis removidas não mantenham o valor antigo.
+	fields := structFields(t)
+	fresh := reflect.New(t).Elem()
+	fresh.Set(current)
+	for _, fi := range fields {
+		if f := fresh.FieldByIndex(fi.index); f.CanSet() {
+			f.Set(reflect.Zero(fi.field.Type))
+		}
+	}
+
+	options := resolveOptions(opts)
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	if err := Load(fresh.Addr().Interface(), opts...); err != nil {
+		logger.Error("config reload failed", "error", err)
+		return err
+	}
+
+	var changed []string
+	for _, fi := range fields {
+		currentField := current.FieldByIndex(fi.index)
+		if isReloadable(fi.field) || !currentField.CanInterface() {
+			continue
+		}
+
+		if !reflect.DeepEqual(currentField.Interface(), fresh.FieldByIndex(fi.index).Interface()) {
+			changed = append(changed, options.Prefix+fi.name)
+		}
+	}
+
+	if len(changed) > 0 {
+		logger.Warn("config reload rejected: restart required", "fields", changed)
+		return &RestartRequiredError{Fields: changed}
+	}
+
+	changes := Diff(current.Interface(), fresh.Interface(), options.Mask)
+	current.Set(fresh)
+
+	attrs := make([]any, 0, len(changes))
+	for _, c := range changes {
+		attrs = append(attrs, slog.Group(options.Prefix+c.Name, "old", c.Old, "new", c.New))
+	}
+	logger.Info("config reloaded", slog.Int("changes", len(changes)), slog.Group("changed", attrs...))
+	return nil
+}
+
+// isReloadable indica se um campo pode ser alterado por Reload.
+// Apenas campos com a tag `reload:"false"` exigem restart.
+func isReloadable(field reflect.StructField) bool {
+	return field.Tag.Get("reload") != "false"
+}
@@ -0,0 +1,195 @@
+package configloader
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// ReloadConfig struct para testes de reload
+type ReloadConfig struct {
+	Port     string `env:"RELOAD_PORT,8080" reload:"false"`
+	LogLevel string `env:"RELOAD_LOG_LEVEL,info"`
+	Internal string
+}
+
+// TestReload_ReloadableField testa reload de campo recarregável
+func TestReload_ReloadableField(t *testing.T) {
+	var cfg ReloadConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	cfg.Internal = "keep"
+
+	os.Setenv("RELOAD_LOG_LEVEL", "debug")
+	defer os.Unsetenv("RELOAD_LOG_LEVEL")
+
+	if err := Reload(&cfg); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if cfg.LogLevel != "debug" {
+		t.Errorf("Expected LogLevel debug, got %s", cfg.LogLevel)
+	}
+
+	if cfg.Internal != "keep" {
+		t.Errorf("Expected untagged field to be preserved, got %s", cfg.Internal)
+	}
+}
+
+// TestReload_RestartRequired testa rejeição quando campo não recarregável muda
+func TestReload_RestartRequired(t *testing.T) {
+	var cfg ReloadConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	os.Setenv("RELOAD_PORT", "9090")
+	os.Setenv("RELOAD_LOG_LEVEL", "debug")
+	defer func() {
+		os.Unsetenv("RELOAD_PORT")
+		os.Unsetenv("RELOAD_LOG_LEVEL")
+	}()
+
+	err := Reload(&cfg)
+	var restartErr *RestartRequiredError
+	if !errors.As(err, &restartErr) {
+		t.Fatalf("Expected RestartRequiredError, got %v", err)
+	}
+
+	if len(restartErr.Fields) != 1 || restartErr.Fields[0] != "RELOAD_PORT" {
+		t.Errorf("Expected [RELOAD_PORT], got %v", restartErr.Fields)
+	}
+
+	// O reload deve ser rejeitado por completo
+	if cfg.Port != "8080" || cfg.LogLevel != "info" {
+		t.Errorf("Expected config unchanged, got Port=%s LogLevel=%s", cfg.Port, cfg.LogLevel)
+	}
+}
+
+// TestReload_RestartRequiredPrefix testa que os nomes reportados incluem o prefixo
+func TestReload_RestartRequiredPrefix(t *testing.T) {
+	var cfg ReloadConfig
+	if err := Load(&cfg, WithPrefix("APP_")); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	t.Setenv("APP_RELOAD_LOG_LEVEL", "debug")
+	var buf bytes.Buffer
+	if err := Reload(&cfg, WithPrefix("APP_"), WithLogger(newTestLogger(&buf))); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "changed.APP_RELOAD_LOG_LEVEL.old=info") {
+		t.Errorf("Expected prefixed name in reload log, got:\n%s", buf.String())
+	}
+
+	t.Setenv("APP_RELOAD_PORT", "9090")
+	err := Reload(&cfg, WithPrefix("APP_"))
+	var restartErr *RestartRequiredError
+	if !errors.As(err, &restartErr) {
+		t.Fatalf("Expected RestartRequiredError, got %v", err)
+	}
+	if len(restartErr.Fields) != 1 || restartErr.Fields[0] != "APP_RELOAD_PORT" {
+		t.Errorf("Expected [APP_RELOAD_PORT], got %v", restartErr.Fields)
+	}
+}
+
+// TestReload_RemovedVariable testa que variável removida volta ao default
+func TestReload_RemovedVariable(t *testing.T) {
+	os.Setenv("RELOAD_LOG_LEVEL", "warn")
+
+	var cfg ReloadConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	os.Unsetenv("RELOAD_LOG_LEVEL")
+
+	if err := Reload(&cfg); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if cfg.LogLevel != "info" {
+		t.Errorf("Expected LogLevel info, got %s", cfg.LogLevel)
+	}
+}
+
+// TestReload_EditedEnvFile testa que Reload aplica mudanças feitas no arquivo .env
+func TestReload_EditedEnvFile(t *testing.T) {
+	path := writeEnvFile(t, "reload.env", "RELOAD_LOG_LEVEL=info\n", "RELOAD_LOG_LEVEL")
+
+	var cfg ReloadConfig
+	if err := Load(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("RELOAD_LOG_LEVEL=warn\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if cfg.LogLevel != "warn" {
+		t.Errorf("Expected LogLevel warn after editing the file, got %s", cfg.LogLevel)
+	}
+}
+
+// TestReload_RemovedFromEnvFile testa que uma chave apagada do arquivo .env deixa de
+// valer em Reload, voltando ao default
+func TestReload_RemovedFromEnvFile(t *testing.T) {
+	path := writeEnvFile(t, "removed.env", "RELOAD_LOG_LEVEL=debug\n", "RELOAD_LOG_LEVEL")
+
+	var cfg ReloadConfig
+	if err := Load(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("# RELOAD_LOG_LEVEL removido\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Reload(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if cfg.LogLevel != "info" {
+		t.Errorf("Expected default LogLevel after removing the key, got %s", cfg.LogLevel)
+	}
+	if _, ok := os.LookupEnv("RELOAD_LOG_LEVEL"); ok {
+		t.Errorf("Expected RELOAD_LOG_LEVEL to be unset")
+	}
+}
+
+// TestReload_SystemValueEqualToFile testa que uma variável alterada fora do loader passa
+// a ser do sistema, mesmo que depois volte ao valor do arquivo
+func TestReload_SystemValueEqualToFile(t *testing.T) {
+	path := writeEnvFile(t, "owner.env", "RELOAD_LOG_LEVEL=debug\n", "RELOAD_LOG_LEVEL")
+
+	var cfg ReloadConfig
+	if err := Load(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	os.Setenv("RELOAD_LOG_LEVEL", "warn")
+	if err := Reload(&cfg, WithFiles(path)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	os.Setenv("RELOAD_LOG_LEVEL", "debug")
+
+	if err := os.WriteFile(path, []byte("RELOAD_LOG_LEVEL=error\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var prov Provenance
+	if err := Reload(&cfg, WithFiles(path), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if cfg.LogLevel != "debug" {
+		t.Errorf("Expected system value to take precedence, got %s", cfg.LogLevel)
+	}
+	if origin, _ := prov.Lookup("RELOAD_LOG_LEVEL"); origin.Kind != OriginSystem {
+		t.Errorf("Expected system origin, got %s", origin)
+	}
+}
@@ -0,0 +1,128 @@
+package configloader
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resolver aplica as regras de Load (defaults, required e validações) a valores
+// lidos de uma Source. É usado pelas funções geradas por configloader-gen, que
+// fazem a conversão de tipos sem reflection; não é necessário usá-lo diretamente.
+//
+// Exemplo (código gerado):
+//
+//	r := configloader.NewResolver(src)
+//	if raw, ok := r.Lookup("PORT", "8080"); ok {
+//	    v, err := configloader.ParseInt(raw)
+//	    if err != nil {
+//	        return cfg, r.FieldError("Port", err)
+//	    }
+//	    cfg.Port = int(v)
+//	}
+//	return cfg, r.Err()
+type Resolver struct {
+	src    Source
+	errors []string
+}
+
+// NewResolver cria um Resolver que lê os valores de src.
+func NewResolver(src Source) *Resolver {
+	return &Resolver{src: src}
+}
+
+// Lookup retorna o valor da variável name na Source ou, se ausente ou vazio, o
+// fallback da tag `env` (o texto após a primeira vírgula). Se o fallback for
+// "required" e não houver valor, registra o erro de validação.
+// ok é false quando não há valor a atribuir ao campo.
+func (r *Resolver) Lookup(name, fallback string) (string, bool) {
+	if value, ok := r.src.Lookup(name); ok && value != "" {
+		return value, true
+	}
+	if fallback == "required" {
+		r.errors = append(r.errors, fmt.Sprintf("%s is required", name))
+		return "", false
+	}
+	return fallback, fallback != ""
+}
+
+// FieldError formata um erro de conversão como Load: "error setting field <path>: ...".
+func (r *Resolver) FieldError(path string, err error) error {
+	return fmt.Errorf("error setting field %s: %w", path, err)
+}
+
+// Check valida um valor já convertido contra as tags `enum`, `min` e `max` de tag
+// e registra a violação como erro de validação. É gerado apenas com -validate. field é o nome do campo Go, usado
+// junto com tag para decidir se o valor é sensível.
+func (r *Resolver) Check(name, field string, value any, tag reflect.StructTag) {
+	fi := fieldInfo{
+		field: reflect.StructField{Name: field, Type: reflect.TypeOf(value), Tag: tag},
+		name:  name,
+	}
+	var mask MaskOptions
+	if err := checkConstraints(fi, reflect.ValueOf(value), mask.isSensitive(fi.field)); err != nil {
+		r.errors = append(r.errors, err.Error())
+	}
+}
+
+// Err retorna os erros de validação acumulados, no mesmo formato de Load, ou nil.
+func (r *Resolver) Err() error {
+	if len(r.errors) > 0 {
+		return fmt.Errorf("validation errors: %s", strings.Join(r.errors, "; "))
+	}
+	return nil
+}
+
+// ParseInt converte um valor para inteiro, com a mesma mensagem de erro de Load.
+func ParseInt(value string) (int64, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer value '%s': %w", value, err)
+	}
+	return n, nil
+}
+
+// ParseFloat converte um valor para float64, com a mesma mensagem de erro de Load.
+func ParseFloat(value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid float value '%s': %w", value, err)
+	}
+	return f, nil
+}
+
+// ParseBool converte um valor para booleano com as regras de parseBool,
+// com a mesma mensagem de erro de Load.
+func ParseBool(value string) (bool, error) {
+	b, err := parseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid boolean value '%s': %w", value, err)
+	}
+	return b, nil
+}
+
+// ParseDuration converte um valor com time.ParseDuration, com a mesma mensagem de erro de Load.
+func ParseDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration value '%s': %w", value, err)
+	}
+	return d, nil
+}
+
+// ParseURL converte um valor com url.Parse, com a mesma mensagem de erro de Load.
+func ParseURL(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL value: %w", err)
+	}
+	return u, nil
+}
+
+// ParseStringSlice converte uma lista separada por vírgulas, como em campos []string.
+func ParseStringSlice(value string) []string {
+	return parseStringSlice(value)
+}
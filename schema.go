@@ -0,0 +1,320 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// schemaDraft é o dialeto de JSON Schema gerado por Schema.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern valida durações no formato aceito por time.ParseDuration.
+const durationPattern = `^[-+]?((\d+(\.\d*)?|\.\d+)(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// SchemaProperty descreve uma variável de ambiente no JSON Schema gerado por Schema.
+type SchemaProperty struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description,omitempty"`
+	Format      string          `json:"format,omitempty"`
+	Pattern     string          `json:"pattern,omitempty"`
+	Default     any             `json:"default,omitempty"`
+	Enum        []any           `json:"enum,omitempty"`
+	Minimum     *float64        `json:"minimum,omitempty"`
+	Maximum     *float64        `json:"maximum,omitempty"`
+	MinLength   *int            `json:"minLength,omitempty"`
+	MaxLength   *int            `json:"maxLength,omitempty"`
+	MinItems    *int            `json:"minItems,omitempty"`
+	MaxItems    *int            `json:"maxItems,omitempty"`
+	Items       *SchemaProperty `json:"items,omitempty"`
+	WriteOnly   bool            `json:"writeOnly,omitempty"`
+
+	// XType é o tipo do campo como usado na documentação (string, int, duration, url, ...).
+	XType string `json:"x-type,omitempty"`
+
+	// XField é o caminho do campo na struct, ex: "Database.Host".
+	XField string `json:"x-field,omitempty"`
+}
+
+// SchemaDocument é o JSON Schema de uma struct de configuração: um objeto cujas
+// propriedades são as variáveis de ambiente, na ordem de declaração dos campos.
+type SchemaDocument struct {
+	Schema     string
+	Title      string
+	Properties []NamedSchemaProperty
+	Required   []string
+}
+
+// NamedSchemaProperty associa o nome da variável de ambiente à sua descrição.
+type NamedSchemaProperty struct {
+	Name     string
+	Property SchemaProperty
+}
+
+// MarshalJSON serializa o documento preservando a ordem das propriedades.
+func (d SchemaDocument) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+	fmt.Fprintf(&b, `"$schema":%s,"title":%s,"type":"object","properties":{`, strconv.Quote(d.Schema), strconv.Quote(d.Title))
+	for i, p := range d.Properties {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		data, err := json.Marshal(p.Property)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "%s:%s", strconv.Quote(p.Name), data)
+	}
+	b.WriteString("}")
+
+	if len(d.Required) > 0 {
+		required, err := json.Marshal(d.Required)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, `,"required":%s`, required)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON lê um documento gerado por Schema, preservando a ordem das propriedades.
+func (d *SchemaDocument) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Schema     string          `json:"$schema"`
+		Title      string          `json:"title"`
+		Properties json.RawMessage `json:"properties"`
+		Required   []string        `json:"required"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = SchemaDocument{Schema: raw.Schema, Title: raw.Title, Required: raw.Required}
+	if len(raw.Properties) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw.Properties))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var p SchemaProperty
+		if err := dec.Decode(&p); err != nil {
+			return fmt.Errorf("property %v: %w", token, err)
+		}
+		d.Properties = append(d.Properties, NamedSchemaProperty{Name: token.(string), Property: p})
+	}
+	return nil
+}
+
+// Schema gera um JSON Schema descrevendo todas as variáveis de ambiente da struct.
+// Cada variável vira uma propriedade com:
+//   - type: derivado do tipo do campo (string, integer, number, boolean, array)
+//   - format/pattern: "uri" para URLs, padrão de time.ParseDuration para durações
+//   - default: o default da tag `env`, convertido para o tipo (omitido em campos sensíveis)
+//   - enum, minimum/maximum, minLength/maxLength, minItems/maxItems: das tags `enum`, `min` e `max`
+//   - description: da tag `desc`
+//   - writeOnly: true para campos sensíveis
+//
+// Variáveis marcadas como required aparecem na lista "required".
+//
+// Parâmetros:
+//   - config: Struct (ou ponteiro) com tags `env`
+//
+// Exemplo:
+//
+//	data, err := Schema(Config{})
+//	os.WriteFile("config.schema.json", data, 0o644)
+//
+// Retorna:
+//   - []byte: Documento JSON Schema indentado
+//   - error: Erro se config não for uma struct
+func Schema(config any) ([]byte, error) {
+	doc, err := BuildSchema(config)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// BuildSchema funciona como Schema, mas retorna o documento sem serializar.
+func BuildSchema(config any) (SchemaDocument, error) {
+	fields, err := configFields(config)
+	if err != nil {
+		return SchemaDocument{}, err
+	}
+
+	doc := SchemaDocument{
+		Schema: schemaDraft,
+		Title:  reflect.Indirect(reflect.ValueOf(config)).Type().Name(),
+	}
+
+	var mask MaskOptions
+	for _, fi := range fields {
+		sensitive := mask.isSensitiveField(fi)
+		t := fi.field.Type
+		if isSecretType(t) {
+			t = t.Field(0).Type
+		}
+
+		p := schemaType(t)
+		p.Description = fi.field.Tag.Get("desc")
+		p.XType = typeName(fi.field.Type)
+		p.XField = fi.path()
+		p.WriteOnly = sensitive
+
+		if defaultValue, ok := fi.defaultValue(); ok && !sensitive {
+			p.Default = schemaDefault(t, defaultValue)
+		}
+
+		if enum, ok := fi.field.Tag.Lookup("enum"); ok {
+			target := &p
+			if p.Items != nil {
+				target = p.Items
+			}
+			elemType := t
+			if t.Kind() == reflect.Slice {
+				elemType = t.Elem()
+			}
+			for _, item := range parseStringSlice(enum) {
+				target.Enum = append(target.Enum, schemaLiteral(elemType, item))
+			}
+		}
+
+		if err := applySchemaBounds(&p, t, fi.field); err != nil {
+			return SchemaDocument{}, fmt.Errorf("%s: %w", fi.name, err)
+		}
+
+		doc.Properties = append(doc.Properties, NamedSchemaProperty{Name: fi.name, Property: p})
+		if fi.required() {
+			doc.Required = append(doc.Required, fi.name)
+		}
+	}
+
+	return doc, nil
+}
+
+// schemaType retorna a propriedade base (type, format, pattern, items) para um tipo de campo.
+func schemaType(t reflect.Type) SchemaProperty {
+	switch t {
+	case durationType:
+		return SchemaProperty{Type: "string", Pattern: durationPattern}
+	case urlType, urlPtrType:
+		return SchemaProperty{Type: "string", Format: "uri"}
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return SchemaProperty{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return SchemaProperty{Type: "number"}
+	case reflect.Bool:
+		return SchemaProperty{Type: "boolean"}
+	case reflect.Slice:
+		return SchemaProperty{Type: "array", Items: &SchemaProperty{Type: "string"}}
+	default:
+		return SchemaProperty{Type: "string"}
+	}
+}
+
+// schemaDefault converte o default da tag `env` para o tipo JSON da propriedade.
+func schemaDefault(t reflect.Type, value string) any {
+	if t.Kind() == reflect.Slice {
+		items := parseStringSlice(value)
+		defaults := make([]any, len(items))
+		for i, item := range items {
+			defaults[i] = item
+		}
+		return defaults
+	}
+	return schemaLiteral(t, value)
+}
+
+// schemaLiteral converte um valor string para número ou booleano quando o tipo do campo exige.
+// Se a conversão falhar, o valor é mantido como string.
+func schemaLiteral(t reflect.Type, value string) any {
+	if t == durationType {
+		return value
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := parseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// applySchemaBounds converte as tags `min` e `max` nas palavras-chave de JSON Schema
+// correspondentes ao tipo. Limites de durações não têm equivalente e são ignorados.
+func applySchemaBounds(p *SchemaProperty, t reflect.Type, field reflect.StructField) error {
+	if t == durationType {
+		return nil
+	}
+
+	for _, bound := range []string{"min", "max"} {
+		limit, ok := field.Tag.Lookup(bound)
+		if !ok {
+			continue
+		}
+
+		n, err := parseLimit(bound, limit, p.Type == "string" || p.Type == "array")
+		if err != nil {
+			return err
+		}
+
+		switch p.Type {
+		case "integer", "number":
+			if bound == "min" {
+				p.Minimum = &n
+			} else {
+				p.Maximum = &n
+			}
+		case "string":
+			length := int(n)
+			if bound == "min" {
+				p.MinLength = &length
+			} else {
+				p.MaxLength = &length
+			}
+		case "array":
+			count := int(n)
+			if bound == "min" {
+				p.MinItems = &count
+			} else {
+				p.MaxItems = &count
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package configloader
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestSchema testa a geração do JSON Schema
+func TestSchema(t *testing.T) {
+	type SchemaConfig struct {
+		Port     int    `env:"PORT,8080" min:"1" max:"65535" desc:"Porta HTTP"`
+		LogLevel string `env:"LOG_LEVEL,info" enum:"debug,info"`
+		Password string `env:"DB_PASSWORD,required"`
+		Database struct {
+			Timeout Secret[int] `env:"DB_TIMEOUT,5"`
+			Hosts   []string    `env:"DB_HOSTS,a,b" max:"3"`
+		}
+		Debug bool `env:"DEBUG,false"`
+	}
+
+	data, err := Schema(SchemaConfig{})
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "SchemaConfig",
+  "type": "object",
+  "properties": {
+    "PORT": {
+      "type": "integer",
+      "description": "Porta HTTP",
+      "default": 8080,
+      "minimum": 1,
+      "maximum": 65535,
+      "x-type": "int",
+      "x-field": "Port"
+    },
+    "LOG_LEVEL": {
+      "type": "string",
+      "default": "info",
+      "enum": [
+        "debug",
+        "info"
+      ],
+      "x-type": "string",
+      "x-field": "LogLevel"
+    },
+    "DB_PASSWORD": {
+      "type": "string",
+      "writeOnly": true,
+      "x-type": "string",
+      "x-field": "Password"
+    },
+    "DB_TIMEOUT": {
+      "type": "integer",
+      "writeOnly": true,
+      "x-type": "int",
+      "x-field": "Database.Timeout"
+    },
+    "DB_HOSTS": {
+      "type": "array",
+      "default": [
+        "a",
+        "b"
+      ],
+      "maxItems": 3,
+      "items": {
+        "type": "string"
+      },
+      "x-type": "list",
+      "x-field": "Database.Hosts"
+    },
+    "DEBUG": {
+      "type": "boolean",
+      "default": false,
+      "x-type": "bool",
+      "x-field": "Debug"
+    }
+  },
+  "required": [
+    "DB_PASSWORD"
+  ]
+}
+`
+	if string(data) != expected {
+		t.Errorf("Unexpected schema:\n%s", data)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Schema is not valid JSON: %v", err)
+	}
+}
+
+// TestSchema_InvalidBound testa erro com tag min inválida
+func TestSchema_InvalidBound(t *testing.T) {
+	type BadConfig struct {
+		Port int `env:"PORT" min:"one"`
+	}
+
+	if _, err := Schema(BadConfig{}); err == nil {
+		t.Error("Expected error for invalid min tag, got nil")
+	}
+}
+
+// TestSchema_BoundParsing testa que Schema e Load interpretam os limites da mesma forma
+func TestSchema_BoundParsing(t *testing.T) {
+	type SpacedConfig struct {
+		Name string `env:"BOUND_NAME,abc" min:" 2 "`
+	}
+	data, err := Schema(SpacedConfig{})
+	if err != nil || !strings.Contains(string(data), `"minLength": 2`) {
+		t.Errorf("Expected minLength 2, got %v:\n%s", err, data)
+	}
+	var spaced SpacedConfig
+	if err := Load(&spaced, WithValidation()); err != nil {
+		t.Errorf("Expected spaced limit accepted by Load, got %v", err)
+	}
+
+	type FractionalConfig struct {
+		Hosts []string `env:"BOUND_HOSTS,a" max:"2.5"`
+	}
+	if _, err := Schema(FractionalConfig{}); err == nil || !strings.Contains(err.Error(), "must be a non-negative integer") {
+		t.Errorf("Expected error for fractional max items in Schema, got %v", err)
+	}
+	var fractional FractionalConfig
+	if err := Load(&fractional, WithValidation()); err == nil || !strings.Contains(err.Error(), "must be a non-negative integer") {
+		t.Errorf("Expected error for fractional max items in Load, got %v", err)
+	}
+}
+
+// TestSchemaDocument_RoundTrip testa a leitura de um schema gerado
+func TestSchemaDocument_RoundTrip(t *testing.T) {
+	data, err := Schema(ConstraintConfig{})
+	if err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	var doc SchemaDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if doc.Title != "ConstraintConfig" || len(doc.Properties) != 6 {
+		t.Fatalf("Unexpected document: %+v", doc)
+	}
+
+	// A ordem das propriedades é preservada
+	if doc.Properties[0].Name != "CHECK_PORT" || doc.Properties[5].Name != "CHECK_PIN" {
+		t.Errorf("Unexpected property order: %s ... %s", doc.Properties[0].Name, doc.Properties[5].Name)
+	}
+	if p := doc.Properties[1].Property; len(p.Enum) != 4 || p.Default != "info" {
+		t.Errorf("Unexpected LOG_LEVEL property: %+v", p)
+	}
+}
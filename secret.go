@@ -0,0 +1,100 @@
+package configloader
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+)
+
+// Secret guarda um valor sensível que não vaza por fmt, JSON ou slog.
+// Pode ser usado como tipo de campo em structs de configuração: o valor é
+// convertido para T com as mesmas regras dos demais campos (string, int, bool, ...).
+//
+// String, GoString, Format, MarshalJSON e LogValue sempre retornam "***MASKED***",
+// então log.Printf("%+v", cfg) não expõe o segredo. Use Reveal para obter o valor real.
+//
+// Exemplo:
+//
+//	type Config struct {
+//	    DBPassword Secret[string] `env:"DB_PASSWORD,required"`
+//	    PinCode    Secret[int]    `env:"PIN_CODE"`
+//	}
+//
+//	fmt.Printf("%+v\n", cfg)          // {DBPassword:***MASKED*** PinCode:***MASKED***}
+//	db.Connect(cfg.DBPassword.Reveal())
+type Secret[T any] struct {
+	value T
+}
+
+// SecretString é um Secret que guarda uma string.
+type SecretString = Secret[string]
+
+// NewSecret cria um Secret com o valor informado.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Reveal retorna o valor real do segredo.
+func (s Secret[T]) Reveal() T {
+	return s.value
+}
+
+// String implementa fmt.Stringer sem revelar o valor.
+func (s Secret[T]) String() string {
+	return maskedValue
+}
+
+// GoString implementa fmt.GoStringer (verbo %#v) sem revelar o valor.
+func (s Secret[T]) GoString() string {
+	return maskedValue
+}
+
+// Format implementa fmt.Formatter para que nenhum verbo (%v, %+v, %s, %d, %x, ...) revele o valor.
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	if verb == 'q' {
+		fmt.Fprint(f, strconv.Quote(maskedValue))
+		return
+	}
+	fmt.Fprint(f, maskedValue)
+}
+
+// MarshalJSON implementa json.Marshaler sem revelar o valor.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(maskedValue)), nil
+}
+
+// LogValue implementa slog.LogValuer sem revelar o valor.
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(maskedValue)
+}
+
+// revealAny retorna o valor real como any, para que as estratégias de
+// mascaramento (last4, hash, length) sejam aplicadas sobre ele.
+func (s Secret[T]) revealAny() any {
+	return s.value
+}
+
+// setSecret converte o valor string para T e o armazena no segredo.
+// É usado por setFieldValue para preencher campos do tipo Secret.
+func (s *Secret[T]) setSecret(value string) error {
+	return setFieldValue(reflect.ValueOf(&s.value).Elem(), value)
+}
+
+// secretSetter é implementado por *Secret[T] para qualquer T.
+type secretSetter interface {
+	setSecret(value string) error
+}
+
+// secretRevealer é implementado por Secret[T] para qualquer T.
+type secretRevealer interface {
+	revealAny() any
+}
+
+// secretSetterType é o reflect.Type da interface secretSetter.
+var secretSetterType = reflect.TypeOf((*secretSetter)(nil)).Elem()
+
+// isSecretType indica se t é um Secret[T].
+func isSecretType(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(secretSetterType)
+}
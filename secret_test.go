@@ -0,0 +1,90 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+// SecretConfig struct para testes de Secret
+type SecretConfig struct {
+	DSN     SecretString     `env:"SECRET_DSN,required"`
+	PinCode Secret[int]      `env:"SECRET_PIN,1234"`
+	Hosts   Secret[[]string] `env:"SECRET_HOSTS,a,b"`
+}
+
+// TestLoad_Secret testa o carregamento de campos Secret
+func TestLoad_Secret(t *testing.T) {
+	os.Setenv("SECRET_DSN", "postgres://user:pw@host/db")
+	defer os.Unsetenv("SECRET_DSN")
+
+	var cfg SecretConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DSN.Reveal() != "postgres://user:pw@host/db" {
+		t.Errorf("Expected DSN to be revealed, got %s", cfg.DSN.Reveal())
+	}
+	if cfg.PinCode.Reveal() != 1234 {
+		t.Errorf("Expected PinCode 1234, got %d", cfg.PinCode.Reveal())
+	}
+	if hosts := cfg.Hosts.Reveal(); len(hosts) != 2 || hosts[1] != "b" {
+		t.Errorf("Expected hosts [a b], got %v", hosts)
+	}
+}
+
+// TestLoad_SecretInvalidValue testa erro de conversão em Secret
+func TestLoad_SecretInvalidValue(t *testing.T) {
+	os.Setenv("SECRET_DSN", "dsn")
+	os.Setenv("SECRET_PIN", "abc")
+	defer func() {
+		os.Unsetenv("SECRET_DSN")
+		os.Unsetenv("SECRET_PIN")
+	}()
+
+	var cfg SecretConfig
+	if err := Load(&cfg); err == nil {
+		t.Error("Expected error for invalid integer, got nil")
+	}
+}
+
+// TestSecret_NoLeak testa que o valor não vaza por fmt, JSON ou slog
+func TestSecret_NoLeak(t *testing.T) {
+	cfg := SecretConfig{DSN: NewSecret("hunter2"), PinCode: NewSecret(9876)}
+
+	outputs := []string{
+		fmt.Sprintf("%v", cfg),
+		fmt.Sprintf("%+v", cfg),
+		fmt.Sprintf("%#v", cfg),
+		fmt.Sprintf("%s %d %x %q", cfg.DSN, cfg.PinCode, cfg.DSN, cfg.DSN),
+		SPrint(cfg, MaskOptions{DisableKeywords: true}),
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	outputs = append(outputs, string(data))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", "dsn", cfg.DSN, "pin", cfg.PinCode)
+	outputs = append(outputs, buf.String())
+
+	if result := SPrint(SecretConfig{DSN: NewSecret("hunter2")}, MaskOptions{Strategy: MaskLast4}); !strings.Contains(result, "****ter2") {
+		t.Errorf("Expected mask strategy applied to revealed value, got:\n%s", result)
+	}
+
+	for _, output := range outputs {
+		if strings.Contains(output, "hunter2") || strings.Contains(output, "9876") || strings.Contains(output, "2694") {
+			t.Errorf("Secret leaked in output: %s", output)
+		}
+		if !strings.Contains(output, maskedValue) {
+			t.Errorf("Expected masked value in output: %s", output)
+		}
+	}
+}
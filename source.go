@@ -0,0 +1,91 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+)
+
+// Source fornece valores de configuração pelo nome da variável.
+// Lookup retorna o valor e se a variável está definida; valores vazios são
+// tratados como ausentes, como nas variáveis de ambiente.
+//
+// Exemplo:
+//
+//	src := MapSource{"PORT": "9090", "DB_HOST": "db.internal"}
+//	err := LoadFromSource(&cfg, src)
+type Source interface {
+	Lookup(name string) (string, bool)
+}
+
+// SourceFunc adapta uma função ao tipo Source.
+type SourceFunc func(name string) (string, bool)
+
+// Lookup chama f(name).
+func (f SourceFunc) Lookup(name string) (string, bool) {
+	return f(name)
+}
+
+// MapSource é uma Source em memória, útil em testes.
+type MapSource map[string]string
+
+// Lookup retorna o valor da chave no mapa.
+func (m MapSource) Lookup(name string) (string, bool) {
+	value, ok := m[name]
+	return value, ok
+}
+
+// EnvSource é a Source das variáveis de ambiente do processo.
+var EnvSource Source = SourceFunc(os.LookupEnv)
+
+// LoadFromSource carrega configurações apenas a partir de src, ignorando variáveis
+// de ambiente do sistema e arquivos .env. Defaults, required, conversão de tipos
+// e validações seguem as mesmas regras de Load.
+//
+// Parâmetros:
+//   - config: Ponteiro para uma struct com tags `env`
+//   - src: Fonte dos valores
+//   - opts: Opções de carregamento (opcional), ex: WithValidation; UseSystem,
+//     EnvFiles e Discovery são ignorados
+//
+// Retorna:
+//   - error: Erro se a validação falhar
+//
+// Exemplo:
+//
+//	err := LoadFromSource(&cfg, MapSource{"PORT": "9090"})
+func LoadFromSource(config any, src Source, opts ...Option) error {
+	options := resolveOptions(opts)
+	options.UseSystem = false
+	options.Sources = append([]Source{src}, options.Sources...)
+	return newLoader(options).load(config)
+}
+
+// fieldSource é implementada por Sources que resolvem um campo pelas tags da struct,
+// além do nome da variável (FileSource, VaultSource). A origem retornada tem Kind,
+// File e Line preenchidos, ou Kind vazio se o campo não foi encontrado. Um erro
+// indica falha ao consultar a fonte, não a ausência do valor.
+type fieldSource interface {
+	lookupField(fi fieldInfo) (string, Origin, error)
+}
+
+// listSource é implementada por Sources que guardam arrays item a item (FileSource),
+// para que campos lista recebam os itens sem dividi-los por vírgula.
+type listSource interface {
+	lookupItems(fi fieldInfo) ([]string, bool)
+}
+
+// referenceResolver é implementada por Sources que resolvem referências em valores
+// vindos de qualquer fonte, como vault://caminho#chave (VaultSource). ok é false se
+// o valor não for uma referência reconhecida.
+type referenceResolver interface {
+	resolveReference(value string) (resolved string, ok bool, err error)
+}
+
+// sourceName retorna o nome de uma Source para trace e provenance: o resultado de
+// String, se a Source implementar fmt.Stringer, ou o tipo dela.
+func sourceName(src Source) string {
+	if s, ok := src.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", src)
+}
@@ -0,0 +1,164 @@
+package configloader
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// StrictMode define como Load trata variáveis que parecem ser de configuração
+// mas não correspondem a nenhuma tag `env` da struct (ex: o erro de digitação
+// APP_DB_HSOT em vez de APP_DB_HOST).
+//
+// São verificadas as chaves dos arquivos .env carregados e, se LoadOptions.Prefix
+// estiver definido, as variáveis do ambiente com o prefixo.
+type StrictMode int
+
+const (
+	// StrictOff não verifica variáveis desconhecidas (padrão).
+	StrictOff StrictMode = iota
+
+	// StrictWarn registra cada variável desconhecida no Logger (nível Warn) e no Trace.
+	StrictWarn
+
+	// StrictError faz Load retornar um *UnknownVariablesError.
+	StrictError
+)
+
+// UnknownVariable é uma variável que não corresponde a nenhuma tag `env`.
+type UnknownVariable struct {
+	// Name é o nome da variável.
+	Name string
+
+	// Source é onde a variável foi encontrada: "system" ou arquivo:linha.
+	Source string
+
+	// Suggestion é a variável conhecida mais parecida, se houver uma próxima o
+	// suficiente (distância de edição pequena).
+	Suggestion string
+}
+
+// String retorna a descrição da variável, ex: "APP_DB_HSOT (.env:3, did you mean APP_DB_HOST?)".
+func (u UnknownVariable) String() string {
+	if u.Suggestion != "" {
+		return fmt.Sprintf("%s (%s, did you mean %s?)", u.Name, u.Source, u.Suggestion)
+	}
+	return fmt.Sprintf("%s (%s)", u.Name, u.Source)
+}
+
+// UnknownVariablesError é retornado por Load em StrictError quando há variáveis desconhecidas.
+type UnknownVariablesError struct {
+	Variables []UnknownVariable
+}
+
+func (e *UnknownVariablesError) Error() string {
+	items := make([]string, len(e.Variables))
+	for i, v := range e.Variables {
+		items[i] = v.String()
+	}
+	return fmt.Sprintf("unknown variables: %s", strings.Join(items, ", "))
+}
+
+// checkUnknown verifica as variáveis desconhecidas conforme o modo strict do loader.
+// Em StrictError, retorna um *UnknownVariablesError; em StrictWarn, apenas registra.
+func (l *loader) checkUnknown(fields []fieldInfo) error {
+	if l.strict == StrictOff {
+		return nil
+	}
+
+	unknown := l.unknownVariables(fields)
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if l.strict == StrictError {
+		return &UnknownVariablesError{Variables: unknown}
+	}
+
+	for _, v := range unknown {
+		l.tracef("unknown variable %s", v)
+		l.logger.Warn("unknown config variable", "name", v.Name, "source", v.Source, "suggestion", v.Suggestion)
+	}
+	return nil
+}
+
+// unknownVariables retorna, ordenadas por nome, as chaves dos arquivos .env carregados
+// e as variáveis do ambiente com o prefixo que não correspondem a nenhum campo.
+func (l *loader) unknownVariables(fields []fieldInfo) []UnknownVariable {
+	known := make(map[string]bool, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, fi := range fields {
+		name := l.prefix + fi.name
+		known[name] = true
+		names = append(names, name)
+		for _, old := range fi.deprecatedNames() {
+			known[l.prefix+old] = true
+		}
+	}
+
+	sources := make(map[string]string)
+	for key, fo := range l.fileKeys {
+		if strings.HasPrefix(key, l.prefix) && !known[key] {
+			sources[key] = fmt.Sprintf("%s:%d", fo.file, fo.line)
+		}
+	}
+
+	if l.prefix != "" {
+		for _, kv := range os.Environ() {
+			key, value, _ := strings.Cut(kv, "=")
+			if _, ok := sources[key]; ok || known[key] || !strings.HasPrefix(key, l.prefix) {
+				continue
+			}
+			sources[key] = envOrigin(key, "", value).String()
+		}
+	}
+
+	unknown := make([]UnknownVariable, 0, len(sources))
+	for key, source := range sources {
+		unknown = append(unknown, UnknownVariable{Name: key, Source: source, Suggestion: suggestName(key, names)})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Name < unknown[j].Name })
+	return unknown
+}
+
+// suggestName retorna o candidato mais próximo de name, se a distância de edição for
+// pequena em relação ao tamanho do nome; caso contrário, "".
+func suggestName(name string, candidates []string) string {
+	limit := max(1, min(3, len(name)/4))
+	best, bestDistance := "", limit+1
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance calcula a distância de Damerau-Levenshtein (alinhamento ótimo) entre
+// a e b: inserções, remoções, substituições e transposições de caracteres vizinhos.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,119 @@
+package configloader
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// StrictConfig struct para testes do modo estrito
+type StrictConfig struct {
+	Host string `env:"DB_HOST,localhost"`
+	Port int    `env:"DB_PORT,5432"`
+}
+
+// TestStrict_UnknownFileKeys testa que chaves desconhecidas do .env geram erro com sugestão
+func TestStrict_UnknownFileKeys(t *testing.T) {
+	path := writeEnvFile(t, "strict.env", "DB_HOST=db.local\nDB_HSOT=typo\nUNRELATED=x\n", "DB_HOST", "DB_HSOT", "UNRELATED")
+
+	var cfg StrictConfig
+	err := Load(&cfg, WithFiles(path), WithStrict(StrictError))
+
+	var unknown *UnknownVariablesError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected *UnknownVariablesError, got %v", err)
+	}
+	if len(unknown.Variables) != 2 {
+		t.Fatalf("Expected 2 unknown variables, got %v", unknown.Variables)
+	}
+
+	typo := unknown.Variables[0]
+	if typo.Name != "DB_HSOT" || typo.Suggestion != "DB_HOST" || typo.Source != path+":2" {
+		t.Errorf("Expected DB_HSOT at %s:2 suggesting DB_HOST, got %+v", path, typo)
+	}
+	if other := unknown.Variables[1]; other.Name != "UNRELATED" || other.Suggestion != "" {
+		t.Errorf("Expected UNRELATED without suggestion, got %+v", other)
+	}
+	if !strings.Contains(err.Error(), "DB_HSOT ("+path+":2, did you mean DB_HOST?)") {
+		t.Errorf("Expected suggestion in message, got %v", err)
+	}
+}
+
+// TestStrict_PrefixedEnvironment testa que apenas variáveis com o prefixo são verificadas
+func TestStrict_PrefixedEnvironment(t *testing.T) {
+	t.Setenv("STRICT_DB_HOST", "db.local")
+	t.Setenv("STRICT_DB_PROT", "1")
+	t.Setenv("OTHER_VAR", "ignored")
+
+	var cfg StrictConfig
+	err := Load(&cfg, WithPrefix("STRICT_"), WithStrict(StrictError))
+	if err == nil || err.Error() != "unknown variables: STRICT_DB_PROT (system, did you mean STRICT_DB_PORT?)" {
+		t.Errorf("Expected unknown STRICT_DB_PROT, got %v", err)
+	}
+	if cfg.Host != "db.local" {
+		t.Errorf("Expected Host 'db.local', got '%s'", cfg.Host)
+	}
+
+	if err := Load(&cfg, WithPrefix("STRICT_")); err != nil {
+		t.Errorf("Expected no error without strict mode, got %v", err)
+	}
+}
+
+// TestStrict_Warn testa que StrictWarn apenas registra as variáveis desconhecidas
+func TestStrict_Warn(t *testing.T) {
+	t.Setenv("WARN_DB_HOTS", "x")
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	var cfg StrictConfig
+	if err := Load(&cfg, WithPrefix("WARN_"), WithStrict(StrictWarn), WithLogger(logger)); err != nil {
+		t.Fatalf("Expected no error in warn mode, got %v", err)
+	}
+	if !strings.Contains(logs.String(), "unknown config variable") || !strings.Contains(logs.String(), "suggestion=WARN_DB_HOST") {
+		t.Errorf("Expected warning with suggestion, got:\n%s", logs.String())
+	}
+}
+
+// TestStrict_WithValidationErrors testa que o erro de variáveis desconhecidas acompanha
+// os erros de validação (um erro de digitação costuma causar um required ausente)
+func TestStrict_WithValidationErrors(t *testing.T) {
+	type RequiredConfig struct {
+		Key string `env:"API_KEY,required"`
+	}
+	t.Setenv("REQ_API_KYE", "secret")
+
+	var cfg RequiredConfig
+	err := Load(&cfg, WithPrefix("REQ_"), WithStrict(StrictError))
+	want := "validation errors: REQ_API_KEY is required; unknown variables: REQ_API_KYE (system, did you mean REQ_API_KEY?)"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected '%s', got %v", want, err)
+	}
+
+	var unknown *UnknownVariablesError
+	if !errors.As(err, &unknown) {
+		t.Errorf("Expected validation error wrapping *UnknownVariablesError, got %v", err)
+	}
+}
+
+// TestEditDistance testa a distância de edição, incluindo transposições
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"DB_HOST", "DB_HOST", 0},
+		{"DB_HSOT", "DB_HOST", 1},
+		{"DB_HOS", "DB_HOST", 1},
+		{"DB_HOST", "DB_PORT", 2},
+		{"", "ABC", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q): Expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
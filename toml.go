@@ -0,0 +1,504 @@
+package configloader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tomlParser interpreta o subconjunto de TOML suportado por NewTOMLSource, acumulando
+// os valores folha pelo caminho da chave (ex: "database.host").
+type tomlParser struct {
+	file   string
+	src    string
+	pos    int
+	line   int
+	values map[string]configValue
+
+	// tables são as tabelas declaradas com [tabela], para detectar duplicatas.
+	tables map[string]int
+}
+
+// parseTOML interpreta o conteúdo de um arquivo TOML. Erros incluem arquivo e linha.
+func parseTOML(file, content string) (map[string]configValue, error) {
+	p := &tomlParser{
+		file:   file,
+		src:    strings.ReplaceAll(content, "\r\n", "\n"),
+		line:   1,
+		values: make(map[string]configValue),
+		tables: make(map[string]int),
+	}
+
+	var table []string
+	for {
+		p.skipBlank(true)
+		if p.pos >= len(p.src) {
+			return p.values, nil
+		}
+
+		if p.src[p.pos] == '[' {
+			if strings.HasPrefix(p.src[p.pos:], "[[") {
+				return nil, p.errorf("arrays of tables are not supported")
+			}
+			p.pos++
+			key, err := p.parseKey(']')
+			if err != nil {
+				return nil, err
+			}
+			p.pos++
+
+			name := strings.Join(key, ".")
+			if first, dup := p.tables[name]; dup {
+				return nil, p.errorf("duplicate table [%s] (first defined at line %d)", name, first)
+			}
+			if _, ok := p.values[name]; ok {
+				return nil, p.errorf("table [%s] redefines a value", name)
+			}
+			p.tables[name] = p.line
+			table = key
+		} else if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// errorf retorna um erro com arquivo e a linha atual.
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s:%d: %s", p.file, p.line, fmt.Sprintf(format, args...))
+}
+
+// skipBlank pula espaços e comentários e, se newlines for true, também quebras de linha.
+func (p *tomlParser) skipBlank(newlines bool) {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == '\n' && newlines:
+			p.pos++
+			p.line++
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine exige que o restante da linha esteja vazio (ou seja um comentário).
+func (p *tomlParser) endOfLine() error {
+	p.skipBlank(false)
+	if p.pos < len(p.src) && p.src[p.pos] != '\n' {
+		return p.errorf("expected end of line, got %q", p.rest())
+	}
+	return nil
+}
+
+// rest retorna o restante da linha atual, para mensagens de erro.
+func (p *tomlParser) rest() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end < 0 {
+		return p.src[p.pos:]
+	}
+	return p.src[p.pos : p.pos+end]
+}
+
+// parseKeyValue lê "chave = valor" e guarda o valor sob table.
+func (p *tomlParser) parseKeyValue(table []string) error {
+	key, err := p.parseKey('=')
+	if err != nil {
+		return err
+	}
+	p.pos++
+	p.skipBlank(false)
+	return p.parseValue(append(append([]string{}, table...), key...))
+}
+
+// parseKey lê uma chave, possivelmente com pontos e aspas, até o delimitador (sem consumi-lo).
+func (p *tomlParser) parseKey(delim byte) ([]string, error) {
+	var key []string
+	for {
+		p.skipBlank(false)
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			return nil, p.errorf("expected %q after key", delim)
+		}
+
+		var segment string
+		switch c := p.src[p.pos]; {
+		case c == '"' || c == '\'':
+			s, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			segment = s
+		default:
+			start := p.pos
+			for p.pos < len(p.src) && isBareKeyChar(p.src[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("invalid key %q", p.rest())
+			}
+			segment = p.src[start:p.pos]
+		}
+		key = append(key, segment)
+
+		p.skipBlank(false)
+		switch {
+		case p.pos < len(p.src) && p.src[p.pos] == '.':
+			p.pos++
+		case p.pos < len(p.src) && p.src[p.pos] == delim:
+			return key, nil
+		default:
+			return nil, p.errorf("expected %q after key %s", delim, strings.Join(key, "."))
+		}
+	}
+}
+
+// parseValue lê o valor de uma chave: escalar, array ou tabela inline.
+func (p *tomlParser) parseValue(path []string) error {
+	name := strings.Join(path, ".")
+	if _, dup := p.values[name]; dup {
+		return p.errorf("duplicate key %s", name)
+	}
+	if _, dup := p.tables[name]; dup {
+		return p.errorf("key %s redefines a table", name)
+	}
+	n := p.line
+
+	if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+		return p.errorf("missing value for %s", name)
+	}
+
+	switch p.src[p.pos] {
+	case '[':
+		items, err := p.parseArray()
+		if err != nil {
+			return err
+		}
+		p.values[name] = listValue(items, n)
+		return nil
+	case '{':
+		return p.parseInlineTable(path)
+	}
+
+	value, err := p.parseScalar()
+	if err != nil {
+		return err
+	}
+	p.values[name] = configValue{value: value, line: n}
+	return nil
+}
+
+// parseArray lê um array de valores escalares, que pode ocupar várias linhas.
+func (p *tomlParser) parseArray() ([]string, error) {
+	p.pos++
+	var items []string
+	for {
+		p.skipBlank(true)
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unterminated array")
+		}
+		if p.src[p.pos] == ']' {
+			p.pos++
+			return items, nil
+		}
+		if c := p.src[p.pos]; c == '[' || c == '{' {
+			return nil, p.errorf("only arrays of scalar values are supported")
+		}
+
+		item, err := p.parseScalar()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipBlank(true)
+		switch {
+		case p.pos < len(p.src) && p.src[p.pos] == ',':
+			p.pos++
+		case p.pos < len(p.src) && p.src[p.pos] == ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// parseInlineTable lê uma tabela inline { a = 1, b.c = "x" } em uma única linha.
+func (p *tomlParser) parseInlineTable(path []string) error {
+	p.pos++
+	p.skipBlank(false)
+	if p.pos < len(p.src) && p.src[p.pos] == '}' {
+		p.pos++
+		return nil
+	}
+
+	for {
+		if err := p.parseKeyValue(path); err != nil {
+			return err
+		}
+		p.skipBlank(false)
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			return p.errorf("unterminated inline table")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return nil
+		default:
+			return p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// parseScalar lê uma string, número, booleano ou data e retorna o texto do valor.
+// Inteiros em hexadecimal, octal ou binário são convertidos para decimal.
+func (p *tomlParser) parseScalar() (string, error) {
+	if c := p.src[p.pos]; c == '"' || c == '\'' {
+		return p.parseString()
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	// Datas com hora podem usar espaço no lugar de T: 1979-05-27 07:32:00Z.
+	if p.pos+1 < len(p.src) && p.src[p.pos] == ' ' && isDate(p.src[start:p.pos]) && isDigit(p.src[p.pos+1]) {
+		p.pos++
+		for p.pos < len(p.src) && !strings.ContainsRune(" \t\n,]}#", rune(p.src[p.pos])) {
+			p.pos++
+		}
+	}
+	token := p.src[start:p.pos]
+
+	switch {
+	case token == "true" || token == "false":
+		return token, nil
+	case token == "":
+		return "", p.errorf("missing value")
+	case isDate(token):
+		return token, nil
+	}
+
+	number := strings.ReplaceAll(token, "_", "")
+	if len(number) > 2 && number[0] == '0' && strings.ContainsRune("xob", rune(number[1])) {
+		n, err := strconv.ParseInt(number, 0, 64)
+		if err != nil {
+			return "", p.errorf("invalid number %q", token)
+		}
+		return strconv.FormatInt(n, 10), nil
+	}
+	if isTOMLNumber(token) {
+		return strings.TrimPrefix(number, "+"), nil
+	}
+	return "", p.errorf("invalid value %q", token)
+}
+
+// isTOMLNumber indica se token é um inteiro ou float decimal válido em TOML: no máximo
+// um sinal, sem zeros à esquerda, "_" apenas entre dígitos e inf/nan em minúsculas.
+// strconv.ParseFloat aceita mais do que isso (ex: "007", "Inf", "1_"), por isso a
+// validação é feita aqui.
+func isTOMLNumber(token string) bool {
+	s := strings.TrimLeft(token, "+-")
+	if len(token)-len(s) > 1 {
+		return false
+	}
+	if s == "inf" || s == "nan" {
+		return true
+	}
+
+	integer, rest := s, ""
+	if i := strings.IndexAny(s, ".eE"); i >= 0 {
+		integer, rest = s[:i], s[i:]
+	}
+	if !isTOMLDigits(integer) || (len(integer) > 1 && integer[0] == '0') {
+		return false
+	}
+
+	if fraction, ok := strings.CutPrefix(rest, "."); ok {
+		i := strings.IndexAny(fraction, "eE")
+		if i < 0 {
+			i = len(fraction)
+		}
+		if !isTOMLDigits(fraction[:i]) {
+			return false
+		}
+		rest = fraction[i:]
+	}
+	if rest == "" {
+		return true
+	}
+
+	// O expoente pode ter sinal e zeros à esquerda.
+	exponent := strings.TrimLeft(rest[1:], "+-")
+	return len(rest[1:])-len(exponent) <= 1 && isTOMLDigits(exponent)
+}
+
+// isTOMLDigits indica se s é uma sequência não vazia de dígitos, com "_" apenas entre
+// dois dígitos.
+func isTOMLDigits(s string) bool {
+	if s == "" || s[0] == '_' || s[len(s)-1] == '_' || strings.Contains(s, "__") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) && s[i] != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseString lê uma string básica ("...") ou literal ('...'), em uma linha ou em
+// várias (delimitada por três aspas).
+func (p *tomlParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	delim := strings.Repeat(string(quote), 3)
+	multiline := strings.HasPrefix(p.src[p.pos:], delim)
+
+	if !multiline {
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] != quote && p.src[p.pos] != '\n' {
+			if quote == '"' && p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.src) || p.src[p.pos] != quote {
+			return "", p.errorf("unterminated string")
+		}
+		raw := p.src[start:p.pos]
+		p.pos++
+		if quote == '\'' {
+			return raw, nil
+		}
+		return p.unescape(raw)
+	}
+
+	p.pos += 3
+	startLine := p.line
+	if strings.HasPrefix(p.src[p.pos:], "\n") {
+		p.pos++
+		p.line++
+	}
+	end := p.pos
+	for {
+		idx := strings.Index(p.src[end:], delim)
+		if idx < 0 {
+			p.line = startLine
+			return "", p.errorf("unterminated multi-line string")
+		}
+		end += idx
+		if quote == '\'' || !escapedAt(p.src, end) {
+			break
+		}
+		end++
+	}
+	// Até duas aspas adicionais pertencem ao conteúdo: """valor"""" termina em "valor"".
+	for i := 0; i < 2 && end+3 < len(p.src) && p.src[end+3] == quote; i++ {
+		end++
+	}
+
+	raw := p.src[p.pos:end]
+	p.line += strings.Count(raw, "\n")
+	p.pos = end + 3
+	if quote == '\'' {
+		return raw, nil
+	}
+	return p.unescape(raw)
+}
+
+// unescape expande os escapes de strings básicas. Em strings de várias linhas, uma
+// barra no fim da linha remove a quebra e os espaços seguintes.
+func (p *tomlParser) unescape(raw string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(raw) {
+			return "", p.errorf("invalid escape at end of string")
+		}
+
+		switch e := raw[i]; e {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'f':
+			b.WriteByte('\f')
+		case 'r':
+			b.WriteByte('\r')
+		case 'e':
+			b.WriteByte(0x1b)
+		case '"', '\\':
+			b.WriteByte(e)
+		case 'u', 'U':
+			size := 4
+			if e == 'U' {
+				size = 8
+			}
+			if i+size >= len(raw) {
+				return "", p.errorf("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(raw[i+1:i+1+size], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return "", p.errorf("invalid unicode escape \\%c%s", e, raw[i+1:i+1+size])
+			}
+			b.WriteRune(rune(code))
+			i += size
+		case ' ', '\t', '\n':
+			rest := strings.TrimLeft(raw[i:], " \t")
+			if !strings.HasPrefix(rest, "\n") {
+				return "", p.errorf("invalid escape \\%c", e)
+			}
+			i = len(raw) - len(strings.TrimLeft(rest, " \t\n")) - 1
+		default:
+			return "", p.errorf("invalid escape \\%c", e)
+		}
+	}
+	return b.String(), nil
+}
+
+// escapedAt indica se o caractere em i é precedido de um número ímpar de barras.
+func escapedAt(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// isBareKeyChar indica se c pode fazer parte de uma chave sem aspas.
+func isBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// isDigit indica se c é um dígito decimal.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isDate indica se o token começa com uma data (AAAA-MM-DD) ou é uma hora (HH:MM:SS).
+func isDate(token string) bool {
+	switch {
+	case len(token) >= 10 && token[4] == '-' && token[7] == '-':
+		return isDigit(token[0]) && isDigit(token[5]) && isDigit(token[8])
+	case len(token) >= 8 && token[2] == ':' && token[5] == ':':
+		return isDigit(token[0]) && isDigit(token[3]) && isDigit(token[6])
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package configloader
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseTOML testa tabelas, chaves com pontos, strings, números e arrays
+func TestParseTOML(t *testing.T) {
+	content := `# configuração
+app_name = "api"   # comentário
+port = 8_080
+mask = 0xff
+enabled = true
+started = 1979-05-27 07:32:00Z
+path = 'C:\temp'
+"quoted key" = "tab\there \u00e9"
+site.url = "https://example.com"
+
+[database]
+host = "db.internal"
+options = { ssl = true, pool.size = 10 }
+hosts = [
+  "a",   # primeiro
+  "b",
+]
+
+[database.replica]
+certificate = """
+line one
+line two"""
+folded = """\
+    first \
+    second"""
+raw = '''
+C:\raw'''
+`
+	values, err := parseTOML("config.toml", content)
+	if err != nil {
+		t.Fatalf("parseTOML failed: %v", err)
+	}
+
+	expected := map[string]configValue{
+		"app_name":                     {value: "api", line: 2},
+		"port":                         {value: "8080", line: 3},
+		"mask":                         {value: "255", line: 4},
+		"enabled":                      {value: "true", line: 5},
+		"started":                      {value: "1979-05-27 07:32:00Z", line: 6},
+		"path":                         {value: `C:\temp`, line: 7},
+		"quoted key":                   {value: "tab\there é", line: 8},
+		"site.url":                     {value: "https://example.com", line: 9},
+		"database.host":                {value: "db.internal", line: 12},
+		"database.options.ssl":         {value: "true", line: 13},
+		"database.options.pool.size":   {value: "10", line: 13},
+		"database.hosts":               listValue([]string{"a", "b"}, 14),
+		"database.replica.certificate": {value: "line one\nline two", line: 20},
+		"database.replica.folded":      {value: "first second", line: 23},
+		"database.replica.raw":         {value: `C:\raw`, line: 26},
+	}
+	for key, want := range expected {
+		if got, ok := values[key]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Key %s: Expected %+v, got %+v (found: %v)", key, want, got, ok)
+		}
+	}
+	if len(values) != len(expected) {
+		t.Errorf("Expected %d values, got %d: %v", len(expected), len(values), values)
+	}
+}
+
+// TestParseTOML_Numbers testa inteiros e floats decimais válidos, inclusive inf e nan
+func TestParseTOML_Numbers(t *testing.T) {
+	content := "a = +inf\nb = -nan\nc = 1e06\nd = 6.626e-34\ne = -0\nf = 0.0\ng = +1_000.5\n"
+	values, err := parseTOML("numbers.toml", content)
+	if err != nil {
+		t.Fatalf("parseTOML failed: %v", err)
+	}
+
+	expected := map[string]string{"a": "inf", "b": "-nan", "c": "1e06", "d": "6.626e-34", "e": "-0", "f": "0.0", "g": "1000.5"}
+	for key, want := range expected {
+		if got := values[key].value; got != want {
+			t.Errorf("Key %s: Expected %q, got %q", key, want, got)
+		}
+	}
+}
+
+// TestParseTOML_Errors testa as mensagens de erro com número da linha
+func TestParseTOML_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"duplicate key", "a = 1\nb = 2\na = 3\n", "config.toml:3: duplicate key a"},
+		{"duplicate table", "[a]\nx = 1\n[a]\n", "config.toml:3: duplicate table [a] (first defined at line 1)"},
+		{"array of tables", "[[servers]]\n", "config.toml:1: arrays of tables are not supported"},
+		{"missing equals", "a = 1\nb 2\n", `config.toml:2: expected '=' after key b`},
+		{"invalid value", "a = hello\n", `config.toml:1: invalid value "hello"`},
+		{"unterminated string", "a = \"open\n", "config.toml:1: unterminated string"},
+		{"unterminated multi-line", "a = 1\nb = \"\"\"\nopen\n", "config.toml:2: unterminated multi-line string"},
+		{"nested array", "a = [[1], [2]]\n", "config.toml:1: only arrays of scalar values are supported"},
+		{"trailing text", "a = 1 2\n", `config.toml:1: expected end of line, got "2"`},
+		{"invalid escape", "a = \"\\q\"\n", `config.toml:1: invalid escape \q`},
+		{"nan suffix", "a = foonan\n", `config.toml:1: invalid value "foonan"`},
+		{"inf suffix", "a = xinf\n", `config.toml:1: invalid value "xinf"`},
+		{"capitalized inf", "a = Inf\n", `config.toml:1: invalid value "Inf"`},
+		{"leading zero", "a = 007\n", `config.toml:1: invalid value "007"`},
+		{"leading zero float", "a = 01.5\n", `config.toml:1: invalid value "01.5"`},
+		{"trailing underscore", "a = 1_\n", `config.toml:1: invalid value "1_"`},
+		{"missing fraction", "a = 1.\n", `config.toml:1: invalid value "1."`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTOML("config.toml", tt.content)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Expected error '%s', got %v", tt.want, err)
+			}
+		})
+	}
+}
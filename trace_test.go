@@ -0,0 +1,92 @@
+package configloader
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestLoad_Trace testa o log de resolução
+func TestLoad_Trace(t *testing.T) {
+	type TraceConfig struct {
+		Host     string `env:"TRACE_HOST,localhost"`
+		Password string `env:"TRACE_PASSWORD"`
+		Port     int    `env:"TRACE_PORT"`
+		Token    string `env:"TRACE_TOKEN,required"`
+	}
+
+	os.Setenv("TRACE_PASSWORD", "s3cr3t")
+	defer os.Unsetenv("TRACE_PASSWORD")
+
+	var buf bytes.Buffer
+	var cfg TraceConfig
+	err := Load(&cfg, LoadOptions{UseSystem: true, Trace: &buf})
+	if err == nil {
+		t.Fatal("Expected required field error, got nil")
+	}
+
+	output := buf.String()
+	expected := []string{
+		`configloader: TRACE_HOST: not set in environment`,
+		`configloader: TRACE_HOST: using tag default: "localhost"`,
+		`configloader: TRACE_PASSWORD: found in environment (system): ***MASKED***`,
+		`configloader: TRACE_PORT: no value and no default, field left unchanged`,
+		`configloader: TRACE_TOKEN: no value and field is required`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected trace line %q, got:\n%s", line, output)
+		}
+	}
+
+	if strings.Contains(output, "s3cr3t") {
+		t.Errorf("Sensitive value leaked in trace:\n%s", output)
+	}
+}
+
+// TestLoad_TraceRejectedValue testa o log de valor rejeitado
+func TestLoad_TraceRejectedValue(t *testing.T) {
+	type TraceConfig struct {
+		Port int `env:"TRACE_PORT"`
+	}
+
+	os.Setenv("TRACE_PORT", "abc")
+	defer os.Unsetenv("TRACE_PORT")
+
+	var buf bytes.Buffer
+	var cfg TraceConfig
+	if err := Load(&cfg, LoadOptions{UseSystem: true, Trace: &buf}); err == nil {
+		t.Fatal("Expected invalid integer error, got nil")
+	}
+
+	if !strings.Contains(buf.String(), `TRACE_PORT: value "abc" rejected`) {
+		t.Errorf("Expected rejected value in trace, got:\n%s", buf.String())
+	}
+}
+
+// TestFindAndLoad_Trace testa o log dos caminhos tentados por FindAndLoad
+func TestFindAndLoad_Trace(t *testing.T) {
+	path := writeEnvFile(t, "custom.env", "TRACE_FOUND=yes\n", "TRACE_FOUND")
+	os.Setenv("ENV_FILE", path)
+	defer os.Unsetenv("ENV_FILE")
+
+	var buf bytes.Buffer
+	var cfg struct {
+		Found string `env:"TRACE_FOUND"`
+	}
+	if err := FindAndLoad(&cfg, LoadOptions{UseSystem: true, Trace: &buf}); err != nil {
+		t.Fatalf("FindAndLoad failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "trying ./config/.env: not found") {
+		t.Errorf("Expected tried paths in trace, got:\n%s", output)
+	}
+	if !strings.Contains(output, "trying "+path+": loaded") {
+		t.Errorf("Expected used path in trace, got:\n%s", output)
+	}
+	if cfg.Found != "yes" {
+		t.Errorf("Expected Found yes, got %s", cfg.Found)
+	}
+}
@@ -0,0 +1,158 @@
+package configloader
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// checkConstraints valida o valor já convertido de um campo contra as tags de validação:
+//
+//   - `enum:"a,b,c"`: o valor (ou cada item, para listas) deve ser um dos listados
+//   - `min:"N"` / `max:"N"`: limites para números e durações; para strings, limites
+//     do tamanho; para listas, limites da quantidade de itens
+//
+// Exemplo:
+//
+//	type Config struct {
+//	    Port     int           `env:"PORT,8080" min:"1" max:"65535"`
+//	    LogLevel string        `env:"LOG_LEVEL,info" enum:"debug,info,warn,error"`
+//	    Timeout  time.Duration `env:"TIMEOUT,30s" min:"1s" max:"5m"`
+//	}
+//
+// As tags só são verificadas com LoadOptions.Validate (WithValidation). Para campos
+// sensíveis, a mensagem de erro não inclui o valor recebido.
+func checkConstraints(fi fieldInfo, field reflect.Value, sensitive bool) error {
+	if secret, ok := field.Interface().(secretRevealer); ok {
+		field = reflect.ValueOf(secret.revealAny())
+	}
+
+	if enum, ok := fi.field.Tag.Lookup("enum"); ok {
+		allowed := parseStringSlice(enum)
+		for _, item := range constraintItems(field) {
+			if !containsString(allowed, item) {
+				if sensitive {
+					return fmt.Errorf("%s must be one of [%s]", fi.name, strings.Join(allowed, ", "))
+				}
+				return fmt.Errorf("%s must be one of [%s], got %q", fi.name, strings.Join(allowed, ", "), item)
+			}
+		}
+	}
+
+	for _, bound := range []string{"min", "max"} {
+		limit, ok := fi.field.Tag.Lookup(bound)
+		if !ok {
+			continue
+		}
+		if err := checkBound(fi.name, field, bound, limit); err != nil {
+			if sensitive {
+				return fmt.Errorf("%s violates the %s constraint", fi.name, bound)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// constraintItems retorna os valores comparados com `enum`: cada item de uma lista
+// ou o próprio valor formatado.
+func constraintItems(field reflect.Value) []string {
+	if field.Kind() == reflect.Slice {
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = fmt.Sprintf("%v", field.Index(i).Interface())
+		}
+		return items
+	}
+	return []string{fmt.Sprintf("%v", field.Interface())}
+}
+
+// checkBound verifica um limite `min` ou `max` de acordo com o tipo do campo.
+func checkBound(name string, field reflect.Value, bound, limit string) error {
+	var actual, expected float64
+	var unit string
+
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(limit))
+		if err != nil {
+			return fmt.Errorf("%s: invalid %s tag %q: %w", name, bound, limit, err)
+		}
+		if outOfBounds(bound, float64(field.Int()), float64(d)) {
+			return fmt.Errorf("%s must be %s %s, got %s", name, boundWord(bound), d, time.Duration(field.Int()))
+		}
+		return nil
+
+	case field.Kind() == reflect.String:
+		actual, unit = float64(utf8.RuneCountInString(field.String())), " characters"
+	case field.Kind() == reflect.Slice:
+		actual, unit = float64(field.Len()), " items"
+	case field.CanInt():
+		actual = float64(field.Int())
+	case field.CanUint():
+		actual = float64(field.Uint())
+	case field.CanFloat():
+		actual = field.Float()
+	default:
+		return nil
+	}
+
+	expected, err := parseLimit(bound, limit, unit != "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	if outOfBounds(bound, actual, expected) {
+		return fmt.Errorf("%s must be %s %s%s, got %s", name, boundWord(bound), strings.TrimSpace(limit), unit, strconv.FormatFloat(actual, 'f', -1, 64))
+	}
+	return nil
+}
+
+// parseLimit interpreta o valor de uma tag `min` ou `max` numérica, ignorando espaços
+// em volta. Limites de tamanho de strings e de quantidade de itens (count) devem ser
+// inteiros não negativos. É usada por Load e por Schema, para que ambos aceitem os
+// mesmos limites.
+func parseLimit(bound, limit string, count bool) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(limit), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s tag %q: %w", bound, limit, err)
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("invalid %s tag %q: must be a finite number", bound, limit)
+	}
+	if count && (n < 0 || n != math.Trunc(n)) {
+		return 0, fmt.Errorf("invalid %s tag %q: must be a non-negative integer", bound, limit)
+	}
+	return n, nil
+}
+
+// outOfBounds indica se actual viola o limite min/max expected.
+func outOfBounds(bound string, actual, expected float64) bool {
+	if bound == "min" {
+		return actual < expected
+	}
+	return actual > expected
+}
+
+// boundWord retorna a descrição do limite usada nas mensagens de erro.
+func boundWord(bound string) string {
+	if bound == "min" {
+		return "at least"
+	}
+	return "at most"
+}
+
+// containsString indica se value está em values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package configloader
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ConstraintConfig struct para testes de validação
+type ConstraintConfig struct {
+	Port     int           `env:"CHECK_PORT,8080" min:"1" max:"65535"`
+	LogLevel string        `env:"CHECK_LOG_LEVEL,info" enum:"debug,info,warn,error"`
+	Timeout  time.Duration `env:"CHECK_TIMEOUT,30s" min:"1s" max:"5m"`
+	Name     string        `env:"CHECK_NAME,app" min:"2" max:"10"`
+	Hosts    []string      `env:"CHECK_HOSTS,a" enum:"a,b,c" max:"2"`
+	PinCode  int           `env:"CHECK_PIN,1234" max:"9999" secret:"true"`
+}
+
+// TestLoad_Constraints testa as tags enum, min e max
+func TestLoad_Constraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		value    string
+		expected string
+	}{
+		{"MinInt", "CHECK_PORT", "0", "CHECK_PORT must be at least 1, got 0"},
+		{"MaxInt", "CHECK_PORT", "70000", "CHECK_PORT must be at most 65535, got 70000"},
+		{"Enum", "CHECK_LOG_LEVEL", "verbose", `CHECK_LOG_LEVEL must be one of [debug, info, warn, error], got "verbose"`},
+		{"MaxDuration", "CHECK_TIMEOUT", "10m", "CHECK_TIMEOUT must be at most 5m0s, got 10m0s"},
+		{"MinLength", "CHECK_NAME", "x", "CHECK_NAME must be at least 2 characters, got 1"},
+		{"MaxLengthRunes", "CHECK_NAME", "configuração", "CHECK_NAME must be at most 10 characters, got 12"},
+		{"EnumItems", "CHECK_HOSTS", "a,d", `CHECK_HOSTS must be one of [a, b, c], got "d"`},
+		{"MaxItems", "CHECK_HOSTS", "a,b,c", "CHECK_HOSTS must be at most 2 items, got 3"},
+		{"Sensitive", "CHECK_PIN", "12345", "CHECK_PIN violates the max constraint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(tt.env, tt.value)
+			defer os.Unsetenv(tt.env)
+
+			var cfg ConstraintConfig
+			err := Load(&cfg, WithValidation())
+			if err == nil {
+				t.Fatal("Expected validation error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expected, err)
+			}
+			if tt.name == "Sensitive" && strings.Contains(err.Error(), "12345") {
+				t.Errorf("Sensitive value leaked in error: %v", err)
+			}
+		})
+	}
+}
+
+// TestLoad_ConstraintsValid testa valores válidos
+func TestLoad_ConstraintsValid(t *testing.T) {
+	os.Setenv("CHECK_LOG_LEVEL", "debug")
+	defer os.Unsetenv("CHECK_LOG_LEVEL")
+	t.Setenv("CHECK_NAME", "ação-ção") // 8 caracteres, 12 bytes
+
+	var cfg ConstraintConfig
+	if err := Load(&cfg, WithValidation()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("Expected LogLevel debug, got %s", cfg.LogLevel)
+	}
+}
+
+// TestLoad_ConstraintsOptIn testa que, sem WithValidation, as tags não rejeitam valores
+func TestLoad_ConstraintsOptIn(t *testing.T) {
+	t.Setenv("CHECK_LOG_LEVEL", "verbose")
+	t.Setenv("CHECK_PORT", "0")
+
+	var cfg ConstraintConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("Expected constraints to be ignored without WithValidation, got %v", err)
+	}
+	if cfg.LogLevel != "verbose" || cfg.Port != 0 {
+		t.Errorf("Expected values loaded as is, got %+v", cfg)
+	}
+}
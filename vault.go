@@ -0,0 +1,367 @@
+package configloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// vaultScheme é o prefixo de referências a segredos do Vault em valores de configuração.
+const vaultScheme = "vault://"
+
+// VaultOptions configura o acesso ao HashiCorp Vault (engine KV versão 2).
+type VaultOptions struct {
+	// Address é o endereço do Vault, ex: "https://vault.internal:8200".
+	// Padrão: variável de ambiente VAULT_ADDR.
+	Address string
+
+	// Token autentica as requisições. Padrão: variável de ambiente VAULT_TOKEN,
+	// se RoleID não for informado.
+	Token string
+
+	// RoleID e SecretID autenticam pelo método AppRole. O token obtido é renovado
+	// (novo login) antes do fim do lease.
+	RoleID   string
+	SecretID string
+
+	// AppRoleMount é o caminho do método AppRole. Padrão: "approle".
+	AppRoleMount string
+
+	// Namespace é enviado no cabeçalho X-Vault-Namespace (Vault Enterprise).
+	Namespace string
+
+	// Path é o segredo padrão: campos sem tag `vault` são procurados nele pelo nome
+	// da variável (ex: a chave DB_PASSWORD de "secret/data/app"). Opcional.
+	Path string
+
+	// CacheTTL é por quanto tempo um segredo lido é reutilizado, inclusive entre
+	// chamadas de Reload. Segredos com lease menor são relidos ao fim do lease.
+	// Padrão: 5 minutos; negativo desabilita o cache.
+	CacheTTL time.Duration
+
+	// HTTPClient é o cliente usado nas requisições. Padrão: cliente com timeout de 10s.
+	HTTPClient *http.Client
+}
+
+// VaultSource é uma Source que lê segredos do HashiCorp Vault (KV versão 2).
+//
+// Campos com a tag `vault:"caminho#chave"` são lidos do segredo e da chave
+// indicados; o caminho pode incluir o segmento data da API ("secret/data/app") ou
+// não ("secret/app"). Valores de qualquer fonte no formato vault://caminho#chave
+// (ex: DB_PASSWORD=vault://secret/app#db_password no .env) são substituídos pelo
+// segredo. Campos com tag `vault` são sempre tratados como sensíveis.
+//
+// O valor do Vault segue a mesma precedência das outras Sources: flags, ambiente e
+// arquivos têm precedência e, se o segredo ou a chave não existirem (404 ou chave
+// ausente), valem o default ou o required da tag `env`. Falhas de acesso (rede,
+// autenticação, permissão, respostas 5xx) são sempre reportadas como erros de
+// validação, mesmo em campos com default: uma indisponibilidade do Vault não deve
+// fazer a aplicação iniciar silenciosamente com o valor de desenvolvimento.
+//
+// Exemplo:
+//
+//	type Config struct {
+//	    DBPassword string `env:"DB_PASSWORD,required" vault:"secret/data/app#db_password"`
+//	}
+//
+//	vault, err := NewVaultSource(VaultOptions{RoleID: roleID, SecretID: secretID})
+//	err = Load(&cfg, WithSource(vault))
+type VaultSource struct {
+	opts   VaultOptions
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	cache       map[string]vaultSecret
+}
+
+// vaultSecret é um segredo em cache; data é nil se o segredo não existe.
+type vaultSecret struct {
+	data    map[string]any
+	expires time.Time
+}
+
+// NewVaultSource cria uma VaultSource. Nenhuma requisição é feita até o primeiro
+// segredo ser lido.
+//
+// Parâmetros:
+//   - opts: Endereço, autenticação e cache
+//
+// Retorna:
+//   - *VaultSource: Fonte dos segredos
+//   - error: Erro se o endereço ou as credenciais não forem informados
+func NewVaultSource(opts VaultOptions) (*VaultSource, error) {
+	if opts.Address == "" {
+		opts.Address = os.Getenv("VAULT_ADDR")
+	}
+	if opts.Token == "" && opts.RoleID == "" {
+		opts.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if opts.Address == "" {
+		return nil, fmt.Errorf("vault address is required (VaultOptions.Address or VAULT_ADDR)")
+	}
+	if opts.Token == "" && (opts.RoleID == "" || opts.SecretID == "") {
+		return nil, fmt.Errorf("vault authentication is required: Token (or VAULT_TOKEN) or RoleID and SecretID")
+	}
+	if opts.AppRoleMount == "" {
+		opts.AppRoleMount = "approle"
+	}
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = 5 * time.Minute
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &VaultSource{
+		opts:   opts,
+		client: client,
+		now:    time.Now,
+		token:  opts.Token,
+		cache:  make(map[string]vaultSecret),
+	}, nil
+}
+
+// String retorna "vault", usado em Trace para valores lidos por Lookup.
+func (s *VaultSource) String() string {
+	return "vault"
+}
+
+// Lookup lê a variável name do segredo padrão (VaultOptions.Path). Retorna false se
+// Path não estiver definido, se a chave não existir ou se a leitura falhar; em Load,
+// falhas de leitura são reportadas como erros de validação.
+func (s *VaultSource) Lookup(name string) (string, bool) {
+	if s.opts.Path == "" {
+		return "", false
+	}
+	value, found, err := s.read(s.opts.Path + "#" + name)
+	return value, found && err == nil
+}
+
+// lookupField lê o campo pela tag `vault` ou, sem a tag, pelo nome da variável no
+// segredo padrão.
+func (s *VaultSource) lookupField(fi fieldInfo) (string, Origin, error) {
+	ref, ok := fi.field.Tag.Lookup("vault")
+	if !ok {
+		if s.opts.Path == "" {
+			return "", Origin{}, nil
+		}
+		ref = s.opts.Path + "#" + fi.name
+	}
+
+	value, found, err := s.read(ref)
+	if err != nil || !found {
+		return "", Origin{}, err
+	}
+	return value, Origin{Kind: OriginSource, File: vaultScheme + ref}, nil
+}
+
+// resolveReference resolve valores vault://caminho#chave. Uma referência a um segredo
+// ou chave inexistente é um erro.
+func (s *VaultSource) resolveReference(value string) (string, bool, error) {
+	ref, ok := strings.CutPrefix(value, vaultScheme)
+	if !ok {
+		return "", false, nil
+	}
+
+	resolved, found, err := s.read(ref)
+	if err != nil {
+		return "", false, err
+	}
+	if !found {
+		return "", false, fmt.Errorf("vault secret %s not found", ref)
+	}
+	return resolved, true, nil
+}
+
+// read lê a chave de um segredo a partir de uma referência caminho#chave.
+func (s *VaultSource) read(ref string) (string, bool, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || key == "" {
+		return "", false, fmt.Errorf("invalid vault reference %q: expected path#key", ref)
+	}
+
+	data, err := s.secret(path)
+	if err != nil {
+		return "", false, fmt.Errorf("error reading vault secret %s: %w", path, err)
+	}
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "", false, nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, true, nil
+	case json.Number, bool:
+		return fmt.Sprint(v), true, nil
+	default:
+		return "", false, fmt.Errorf("vault secret %s: key %s is not a scalar value", path, key)
+	}
+}
+
+// secret retorna os dados de um segredo, do cache ou do Vault. Retorna nil se o
+// segredo não existir.
+func (s *VaultSource) secret(path string) (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apiPath := kvDataPath(path)
+	if cached, ok := s.cache[apiPath]; ok && s.now().Before(cached.expires) {
+		return cached.data, nil
+	}
+
+	var response struct {
+		LeaseDuration int `json:"lease_duration"`
+		Data          struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	found, err := s.request(http.MethodGet, "/v1/"+apiPath, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.opts.CacheTTL
+	if lease := time.Duration(response.LeaseDuration) * time.Second; lease > 0 && lease < ttl {
+		ttl = lease
+	}
+	data := response.Data.Data
+	if !found {
+		data = nil
+	}
+	if ttl > 0 {
+		s.cache[apiPath] = vaultSecret{data: data, expires: s.now().Add(ttl)}
+	}
+	return data, nil
+}
+
+// request faz uma requisição autenticada e decodifica a resposta em out. Retorna
+// false se o Vault responder 404. Com AppRole, um 403 causa um novo login e uma
+// nova tentativa. Deve ser chamada com s.mu bloqueado.
+func (s *VaultSource) request(method, path string, body any, out any) (bool, error) {
+	for attempt := 0; ; attempt++ {
+		if err := s.authenticate(); err != nil {
+			return false, err
+		}
+
+		status, err := s.do(method, path, s.token, body, out)
+		if status == http.StatusForbidden && s.opts.RoleID != "" && attempt == 0 {
+			s.token = ""
+			continue
+		}
+		if status == http.StatusNotFound {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
+// authenticate faz login por AppRole se não houver token ou se o lease do token
+// estiver perto do fim. Deve ser chamada com s.mu bloqueado.
+func (s *VaultSource) authenticate() error {
+	if s.opts.RoleID == "" {
+		return nil
+	}
+	if s.token != "" && (s.tokenExpiry.IsZero() || s.now().Before(s.tokenExpiry)) {
+		return nil
+	}
+
+	var response struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	login := map[string]string{"role_id": s.opts.RoleID, "secret_id": s.opts.SecretID}
+	if _, err := s.do(http.MethodPost, "/v1/auth/"+s.opts.AppRoleMount+"/login", "", login, &response); err != nil {
+		return fmt.Errorf("vault approle login failed: %w", err)
+	}
+	if response.Auth.ClientToken == "" {
+		return fmt.Errorf("vault approle login failed: no client token in response")
+	}
+
+	s.token = response.Auth.ClientToken
+	s.tokenExpiry = time.Time{}
+	if lease := time.Duration(response.Auth.LeaseDuration) * time.Second; lease > 0 {
+		// Renova com 10% do lease de folga.
+		s.tokenExpiry = s.now().Add(lease - lease/10)
+	}
+	return nil
+}
+
+// do executa uma requisição HTTP ao Vault e retorna o status. Respostas diferentes
+// de 2xx (exceto 404) são erros com as mensagens retornadas pelo Vault.
+func (s *VaultSource) do(method, path, token string, body any, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimRight(s.opts.Address, "/")+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if s.opts.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.opts.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		// O corpo é opcional: sem mensagens legíveis, o erro informa apenas o status.
+		_ = json.NewDecoder(resp.Body).Decode(&vaultErr)
+		if len(vaultErr.Errors) > 0 {
+			return resp.StatusCode, fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+		}
+		return resp.StatusCode, fmt.Errorf("%s %s: status %d", method, path, resp.StatusCode)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%s %s: invalid response: %w", method, path, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// kvDataPath converte o caminho de um segredo no caminho da API do KV v2, inserindo
+// o segmento data após o mount se necessário: "secret/app" → "secret/data/app".
+func kvDataPath(path string) string {
+	path = strings.Trim(path, "/")
+	mount, rest, ok := strings.Cut(path, "/")
+	if !ok || strings.HasPrefix(rest, "data/") {
+		return path
+	}
+	return mount + "/data/" + rest
+}
@@ -0,0 +1,312 @@
+package configloader
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// VaultConfig struct para testes do Vault
+type VaultConfig struct {
+	DBPassword string `env:"VAULT_DB_PASSWORD,required" vault:"secret/data/app#db_password"`
+	APIKey     string `env:"VAULT_API_KEY,fallback" vault:"secret/app#missing"`
+	Port       int    `env:"VAULT_PORT,8080" vault:"secret/app#port"`
+	Host       string `env:"VAULT_HOST"`
+}
+
+// fakeVault é um servidor Vault mínimo (KV v2 e AppRole) para testes.
+type fakeVault struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	secrets  map[string]map[string]any
+	token    string
+	lease    int
+	tokenTTL int
+	reads    int
+	logins   int
+	fail     bool
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	t.Helper()
+	v := &fakeVault{
+		token: "root",
+		secrets: map[string]map[string]any{
+			"secret/data/app": {"db_password": "s3cr3t", "port": json.Number("9090"), "host": "vault.db"},
+		},
+	}
+	v.Server = httptest.NewServer(http.HandlerFunc(v.handle))
+	t.Cleanup(v.Close)
+	return v
+}
+
+func (v *fakeVault) handle(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		v.logins++
+		v.token = "approle-token-" + string(rune('0'+v.logins))
+		json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": v.token, "lease_duration": v.tokenTTL}})
+		return
+	}
+
+	if v.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]any{"errors": []string{"internal error"}})
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != v.token {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+
+	v.reads++
+	data, ok := v.secrets[strings.TrimPrefix(r.URL.Path, "/v1/")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"errors": []string{}})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"lease_duration": v.lease, "data": map[string]any{"data": data}})
+}
+
+// TestVaultSource_Token testa a leitura por tag `vault`, a precedência do ambiente e
+// o default/required quando o segredo ou a chave não existem
+func TestVaultSource_Token(t *testing.T) {
+	server := newFakeVault(t)
+	vault, err := NewVaultSource(VaultOptions{Address: server.URL, Token: "root"})
+	if err != nil {
+		t.Fatalf("NewVaultSource failed: %v", err)
+	}
+
+	var prov Provenance
+	var cfg VaultConfig
+	if err := Load(&cfg, WithSource(vault), WithProvenance(&prov)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.DBPassword != "s3cr3t" || cfg.Port != 9090 {
+		t.Errorf("Expected values from vault, got %+v", cfg)
+	}
+	if cfg.APIKey != "fallback" {
+		t.Errorf("Expected default for missing key, got '%s'", cfg.APIKey)
+	}
+	if origin, _ := prov.Lookup("VAULT_DB_PASSWORD"); origin.String() != "vault://secret/data/app#db_password" {
+		t.Errorf("Expected vault origin, got %s", origin)
+	}
+
+	t.Setenv("VAULT_PORT", "7000")
+	if err := Load(&cfg, WithSource(vault)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Port != 7000 {
+		t.Errorf("Expected environment to take precedence, got %d", cfg.Port)
+	}
+
+	delete(server.secrets, "secret/data/app")
+	missing, _ := NewVaultSource(VaultOptions{Address: server.URL, Token: "root"})
+	err = Load(&cfg, WithSource(missing))
+	if err == nil || !strings.Contains(err.Error(), "VAULT_DB_PASSWORD") {
+		t.Errorf("Expected required error for missing secret, got %v", err)
+	}
+}
+
+// TestVaultSource_Reference testa a resolução de valores vault://caminho#chave
+func TestVaultSource_Reference(t *testing.T) {
+	server := newFakeVault(t)
+	vault, _ := NewVaultSource(VaultOptions{Address: server.URL, Token: "root"})
+
+	t.Setenv("VAULT_HOST", "vault://secret/app#host")
+	var cfg VaultConfig
+	if err := Load(&cfg, WithSource(vault)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "vault.db" {
+		t.Errorf("Expected Host resolved from vault, got '%s'", cfg.Host)
+	}
+
+	t.Setenv("VAULT_HOST", "vault://secret/app#nope")
+	err := Load(&cfg, WithSource(vault))
+	if err == nil || !strings.Contains(err.Error(), "VAULT_HOST: vault secret secret/app#nope not found") {
+		t.Errorf("Expected not found error for reference, got %v", err)
+	}
+}
+
+// TestVaultSource_DefaultPath testa a busca pelo nome da variável no segredo padrão
+func TestVaultSource_DefaultPath(t *testing.T) {
+	server := newFakeVault(t)
+	server.secrets["secret/data/shared"] = map[string]any{"VAULT_HOST": "shared.db"}
+	vault, _ := NewVaultSource(VaultOptions{Address: server.URL, Token: "root", Path: "secret/shared"})
+
+	if v, ok := vault.Lookup("VAULT_HOST"); !ok || v != "shared.db" {
+		t.Errorf("Expected Lookup 'shared.db', got '%s'", v)
+	}
+
+	var cfg VaultConfig
+	if err := Load(&cfg, WithSource(vault)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Host != "shared.db" {
+		t.Errorf("Expected Host from default path, got '%s'", cfg.Host)
+	}
+}
+
+// TestVaultSource_AppRole testa o login por AppRole, a renovação antes do fim do lease
+// e o novo login quando o token é revogado
+func TestVaultSource_AppRole(t *testing.T) {
+	server := newFakeVault(t)
+	server.tokenTTL = 100
+	vault, err := NewVaultSource(VaultOptions{Address: server.URL, RoleID: "role", SecretID: "secret", CacheTTL: -1})
+	if err != nil {
+		t.Fatalf("NewVaultSource failed: %v", err)
+	}
+	now := time.Now()
+	vault.now = func() time.Time { return now }
+
+	var cfg VaultConfig
+	if err := Load(&cfg, WithSource(vault)); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.DBPassword != "s3cr3t" || server.logins != 1 {
+		t.Errorf("Expected 1 login, got %d (%+v)", server.logins, cfg)
+	}
+
+	now = now.Add(80 * time.Second)
+	if _, _, err := vault.read("secret/app#host"); err != nil || server.logins != 1 {
+		t.Errorf("Expected token reuse before renewal, got %d logins (%v)", server.logins, err)
+	}
+
+	now = now.Add(15 * time.Second)
+	if _, _, err := vault.read("secret/app#host"); err != nil || server.logins != 2 {
+		t.Errorf("Expected renewal near lease end, got %d logins (%v)", server.logins, err)
+	}
+
+	server.token = "revoked"
+	if v, _, err := vault.read("secret/app#host"); err != nil || v != "vault.db" || server.logins != 3 {
+		t.Errorf("Expected new login after 403, got %d logins (%v)", server.logins, err)
+	}
+
+	bad, _ := NewVaultSource(VaultOptions{Address: server.URL, RoleID: "role", SecretID: "wrong"})
+	if _, _, err := bad.read("secret/app#host"); err == nil || !strings.Contains(err.Error(), "vault approle login failed") || !strings.Contains(err.Error(), "invalid role or secret ID") {
+		t.Errorf("Expected login error, got %v", err)
+	}
+}
+
+// TestVaultSource_Cache testa o cache de segredos e o limite pelo lease
+func TestVaultSource_Cache(t *testing.T) {
+	server := newFakeVault(t)
+	vault, _ := NewVaultSource(VaultOptions{Address: server.URL, Token: "root", CacheTTL: time.Minute})
+	now := time.Now()
+	vault.now = func() time.Time { return now }
+
+	var cfg VaultConfig
+	for range 3 {
+		if err := Load(&cfg, WithSource(vault)); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+	}
+	if server.reads != 1 {
+		t.Errorf("Expected 1 read with cache, got %d", server.reads)
+	}
+
+	now = now.Add(2 * time.Minute)
+	vault.read("secret/app#host")
+	if server.reads != 2 {
+		t.Errorf("Expected read after cache expiry, got %d", server.reads)
+	}
+
+	server.lease = 10
+	now = now.Add(2 * time.Minute)
+	vault.read("secret/app#host")
+	now = now.Add(11 * time.Second)
+	vault.read("secret/app#host")
+	if server.reads != 4 {
+		t.Errorf("Expected read after lease expiry, got %d", server.reads)
+	}
+}
+
+// TestVaultSource_Errors testa erros do servidor e de configuração
+func TestVaultSource_Errors(t *testing.T) {
+	server := newFakeVault(t)
+	server.fail = true
+	vault, _ := NewVaultSource(VaultOptions{Address: server.URL, Token: "root"})
+
+	var cfg VaultConfig
+	err := Load(&cfg, WithSource(vault))
+	if err == nil || !strings.Contains(err.Error(), "VAULT_DB_PASSWORD: error reading vault secret secret/data/app: GET /v1/secret/data/app: status 500: internal error") {
+		t.Errorf("Expected server error as validation error, got %v", err)
+	}
+	// Falhas de acesso não caem no default, ao contrário de segredos ausentes
+	if err == nil || !strings.Contains(err.Error(), "VAULT_API_KEY: error reading vault secret") || !strings.Contains(err.Error(), "VAULT_PORT: error reading vault secret") {
+		t.Errorf("Expected server error for fields with default, got %v", err)
+	}
+	if cfg.APIKey == "fallback" || cfg.Port == 8080 {
+		t.Errorf("Expected defaults not to be applied on server error, got %+v", cfg)
+	}
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>bad gateway</html>", http.StatusBadGateway)
+	}))
+	defer gateway.Close()
+	proxied, _ := NewVaultSource(VaultOptions{Address: gateway.URL, Token: "root"})
+	if _, _, err := proxied.read("secret/app#host"); err == nil || !strings.HasSuffix(err.Error(), "GET /v1/secret/data/app: status 502") {
+		t.Errorf("Expected status-only error for non-JSON body, got %v", err)
+	}
+
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	if _, err := NewVaultSource(VaultOptions{Token: "root"}); err == nil || !strings.Contains(err.Error(), "vault address is required") {
+		t.Errorf("Expected address error, got %v", err)
+	}
+	if _, err := NewVaultSource(VaultOptions{Address: server.URL, RoleID: "role"}); err == nil || !strings.Contains(err.Error(), "vault authentication is required") {
+		t.Errorf("Expected authentication error, got %v", err)
+	}
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "root")
+	if _, err := NewVaultSource(VaultOptions{}); err != nil {
+		t.Errorf("Expected options from VAULT_ADDR and VAULT_TOKEN, got %v", err)
+	}
+	if _, _, err := vault.read("secret/app"); err == nil || !strings.Contains(err.Error(), "expected path#key") {
+		t.Errorf("Expected invalid reference error, got %v", err)
+	}
+}
+
+// TestKVDataPath testa a inserção do segmento data no caminho do KV v2
+func TestKVDataPath(t *testing.T) {
+	tests := map[string]string{
+		"secret/app":      "secret/data/app",
+		"secret/data/app": "secret/data/app",
+		"/kv/team/app/":   "kv/data/team/app",
+		"secret":          "secret",
+	}
+	for input, expected := range tests {
+		if got := kvDataPath(input); got != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, input, got)
+		}
+	}
+}
+
+// TestVaultSource_Masking testa que campos com tag `vault` são mascarados
+func TestVaultSource_Masking(t *testing.T) {
+	type Config struct {
+		Conn string `env:"VAULT_CONN" vault:"secret/app#conn"`
+	}
+	out := SPrint(Config{Conn: "postgres://u:p@h/db"})
+	if strings.Contains(out, "postgres") || !strings.Contains(out, maskedValue) {
+		t.Errorf("Expected vault field to be masked, got %s", out)
+	}
+}
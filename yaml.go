@@ -0,0 +1,522 @@
+package configloader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// yamlParser interpreta o subconjunto de YAML suportado por NewYAMLSource, acumulando
+// os valores folha pelo caminho da chave (ex: "database.host").
+type yamlParser struct {
+	file   string
+	lines  []string
+	pos    int
+	values map[string]configValue
+}
+
+// yamlLine é uma linha com conteúdo: indentação, texto sem a indentação e número (a partir de 1).
+type yamlLine struct {
+	indent int
+	text   string
+	n      int
+}
+
+// parseYAML interpreta o conteúdo de um arquivo YAML. Erros incluem arquivo e linha.
+func parseYAML(file, content string) (map[string]configValue, error) {
+	p := &yamlParser{
+		file:   file,
+		lines:  strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n"),
+		values: make(map[string]configValue),
+	}
+
+	// Diretivas e o marcador de início do documento são ignorados.
+	for {
+		line, ok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || (line.indent == 0 && !strings.HasPrefix(line.text, "%") && !isDocumentMarker(line.text, "---")) {
+			break
+		}
+		p.pos = line.n
+	}
+
+	line, ok, err := p.peek()
+	if err != nil || !ok {
+		return p.values, err
+	}
+	if isSequenceItem(line.text) {
+		return nil, p.errorf(line.n, "top-level value must be a mapping")
+	}
+	if err := p.parseMapping(nil, line.indent); err != nil {
+		return nil, err
+	}
+
+	if line, ok, err := p.peek(); err != nil {
+		return nil, err
+	} else if ok {
+		if isDocumentMarker(line.text, "---") {
+			return nil, p.errorf(line.n, "multiple documents are not supported")
+		}
+		if !isDocumentMarker(line.text, "...") {
+			return nil, p.errorf(line.n, "unexpected indentation")
+		}
+	}
+	return p.values, nil
+}
+
+// errorf retorna um erro com arquivo e linha.
+func (p *yamlParser) errorf(n int, format string, args ...any) error {
+	return fmt.Errorf("%s:%d: %s", p.file, n, fmt.Sprintf(format, args...))
+}
+
+// peek retorna a próxima linha com conteúdo, sem consumi-la, pulando linhas em
+// branco e comentários.
+func (p *yamlParser) peek() (yamlLine, bool, error) {
+	for i := p.pos; i < len(p.lines); i++ {
+		raw := p.lines[i]
+		text := strings.TrimLeft(raw, " \t")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		indent := len(raw) - len(text)
+		if strings.Contains(raw[:indent], "\t") {
+			return yamlLine{}, false, p.errorf(i+1, "tabs are not allowed in indentation")
+		}
+		return yamlLine{indent: indent, text: strings.TrimRight(text, " \t"), n: i + 1}, true, nil
+	}
+	return yamlLine{}, false, nil
+}
+
+// parseMapping lê as chaves de um mapa com a indentação informada.
+func (p *yamlParser) parseMapping(path []string, indent int) error {
+	seen := make(map[string]int)
+	for {
+		line, ok, err := p.peek()
+		if err != nil {
+			return err
+		}
+		if !ok || line.indent < indent || isDocumentMarker(line.text, "---") || isDocumentMarker(line.text, "...") {
+			return nil
+		}
+		if line.indent > indent {
+			return p.errorf(line.n, "unexpected indentation")
+		}
+		if isSequenceItem(line.text) {
+			return p.errorf(line.n, "expected a mapping key, found a sequence item")
+		}
+
+		key, rest, err := p.splitKey(line)
+		if err != nil {
+			return err
+		}
+		if first, dup := seen[key]; dup {
+			return p.errorf(line.n, "duplicate key %q (first defined at line %d)", key, first)
+		}
+		seen[key] = line.n
+		p.pos = line.n
+
+		childPath := append(append([]string{}, path...), key)
+		rest = stripYAMLComment(rest)
+
+		switch {
+		case rest == "":
+			next, ok, err := p.peek()
+			if err != nil {
+				return err
+			}
+			switch {
+			case !ok:
+			case next.indent > indent && isSequenceItem(next.text):
+				err = p.parseSequence(childPath, next.indent)
+			case next.indent == indent && isSequenceItem(next.text):
+				err = p.parseSequence(childPath, indent)
+			case next.indent > indent:
+				err = p.parseMapping(childPath, next.indent)
+			}
+			if err != nil {
+				return err
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			value, err := p.blockScalar(line, indent, rest)
+			if err != nil {
+				return err
+			}
+			p.set(childPath, value, line.n)
+		default:
+			if err := p.setValue(childPath, rest, line.n); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseSequence lê os itens de uma lista em bloco e guarda os valores separados por vírgula.
+func (p *yamlParser) parseSequence(path []string, indent int) error {
+	var items []string
+	first := 0
+	for {
+		line, ok, err := p.peek()
+		if err != nil {
+			return err
+		}
+		if !ok || line.indent < indent || (line.indent == indent && !isSequenceItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return p.errorf(line.n, "unexpected indentation")
+		}
+		p.pos = line.n
+		if first == 0 {
+			first = line.n
+		}
+
+		item := stripYAMLComment(strings.TrimPrefix(line.text, "-"))
+		if item == "" || isSequenceItem(item) || item[0] == '[' || item[0] == '{' || hasMappingKey(item) {
+			return p.errorf(line.n, "only lists of scalar values are supported")
+		}
+		value, null, err := p.scalar(item, line.n)
+		if err != nil {
+			return err
+		}
+		if !null {
+			items = append(items, value)
+		}
+	}
+
+	p.values[strings.Join(path, ".")] = listValue(items, first)
+	return nil
+}
+
+// setValue guarda um valor escrito na mesma linha da chave: escalar ou lista [a, b].
+func (p *yamlParser) setValue(path []string, raw string, n int) error {
+	switch raw[0] {
+	case '[':
+		if !strings.HasSuffix(raw, "]") {
+			return p.errorf(n, "unterminated flow sequence")
+		}
+		var items []string
+		for _, item := range splitFlowItems(raw[1 : len(raw)-1]) {
+			if item == "" {
+				continue
+			}
+			if item[0] == '[' || item[0] == '{' {
+				return p.errorf(n, "only lists of scalar values are supported")
+			}
+			value, null, err := p.scalar(item, n)
+			if err != nil {
+				return err
+			}
+			if !null {
+				items = append(items, value)
+			}
+		}
+		p.values[strings.Join(path, ".")] = listValue(items, n)
+		return nil
+	case '{':
+		if raw == "{}" {
+			return nil
+		}
+		return p.errorf(n, "flow mappings are not supported")
+	}
+
+	value, null, err := p.scalar(raw, n)
+	if err != nil || null {
+		return err
+	}
+	p.set(path, value, n)
+	return nil
+}
+
+// yamlEscapes são os escapes de um caractere de strings com aspas duplas (YAML 1.2,
+// seção 5.7); \x, \u e \U são tratados em unescape.
+var yamlEscapes = map[byte]string{
+	'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v",
+	'f': "\f", 'r': "\r", 'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\",
+	'N': "\u0085", '_': "\u00a0", 'L': "\u2028", 'P': "\u2029",
+}
+
+// unescape expande os escapes de uma string com aspas duplas, sem as aspas, seguindo
+// a especificação YAML (e não a sintaxe de Go, como strconv.Unquote).
+func (p *yamlParser) unescape(raw string, n int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c == '"' {
+			return "", p.errorf(n, "unexpected \" inside double-quoted string")
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(raw) {
+			return "", p.errorf(n, "invalid escape at end of string")
+		}
+
+		e := raw[i]
+		if s, ok := yamlEscapes[e]; ok {
+			b.WriteString(s)
+			continue
+		}
+
+		var size int
+		switch e {
+		case 'x':
+			size = 2
+		case 'u':
+			size = 4
+		case 'U':
+			size = 8
+		default:
+			return "", p.errorf(n, "invalid escape \\%c", e)
+		}
+		if i+size >= len(raw) {
+			return "", p.errorf(n, "invalid escape \\%s", raw[i:])
+		}
+		code, err := strconv.ParseUint(raw[i+1:i+1+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", p.errorf(n, "invalid escape \\%c%s", e, raw[i+1:i+1+size])
+		}
+		b.WriteRune(rune(code))
+		i += size
+	}
+	return b.String(), nil
+}
+
+// scalar interpreta um valor escalar: com aspas simples, duplas ou sem aspas.
+// null e ~ indicam ausência de valor.
+func (p *yamlParser) scalar(raw string, n int) (value string, null bool, err error) {
+	switch raw[0] {
+	case '"':
+		if len(raw) < 2 || !strings.HasSuffix(raw, `"`) {
+			return "", false, p.errorf(n, "unterminated double-quoted string")
+		}
+		value, err := p.unescape(raw[1:len(raw)-1], n)
+		if err != nil {
+			return "", false, err
+		}
+		return value, false, nil
+	case '\'':
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", false, p.errorf(n, "unterminated single-quoted string")
+		}
+		return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'"), false, nil
+	case '&', '*':
+		return "", false, p.errorf(n, "anchors and aliases are not supported")
+	case '!':
+		return "", false, p.errorf(n, "tags are not supported")
+	}
+
+	switch raw {
+	case "~", "null", "Null", "NULL":
+		return "", true, nil
+	}
+	return raw, false, nil
+}
+
+// blockScalar lê um bloco literal (|) ou dobrado (>) iniciado na linha da chave.
+// Indicadores de chomping: "-" remove a quebra de linha final, "+" mantém todas.
+func (p *yamlParser) blockScalar(header yamlLine, parentIndent int, indicator string) (string, error) {
+	style, chomp := indicator[0], strings.TrimSpace(indicator[1:])
+	if chomp != "" && chomp != "-" && chomp != "+" {
+		return "", p.errorf(header.n, "unsupported block scalar indicator %q", indicator)
+	}
+
+	var lines []string
+	contentIndent := -1
+	for p.pos < len(p.lines) {
+		raw := p.lines[p.pos]
+		text := strings.TrimLeft(raw, " ")
+		if strings.TrimSpace(raw) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+
+		indent := len(raw) - len(text)
+		if contentIndent < 0 {
+			if indent <= parentIndent {
+				break
+			}
+			contentIndent = indent
+		}
+		if indent < contentIndent {
+			break
+		}
+		lines = append(lines, raw[contentIndent:])
+		p.pos++
+	}
+
+	// Linhas em branco ao final pertencem ao bloco apenas para o chomping.
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	var value string
+	if style == '|' {
+		value = strings.Join(lines, "\n")
+	} else {
+		var b strings.Builder
+		for i, line := range lines {
+			switch {
+			case i == 0:
+			case line == "" || lines[i-1] == "":
+				b.WriteString("\n")
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(line)
+		}
+		value = b.String()
+	}
+
+	switch chomp {
+	case "-":
+		return value, nil
+	case "+":
+		return value + strings.Repeat("\n", trailing+1), nil
+	default:
+		return value + "\n", nil
+	}
+}
+
+// set guarda o valor pelo caminho da chave.
+func (p *yamlParser) set(path []string, value string, n int) {
+	p.values[strings.Join(path, ".")] = configValue{value: value, line: n}
+}
+
+// splitKey separa "chave: resto" de uma linha de mapa. A chave pode ter aspas.
+func (p *yamlParser) splitKey(line yamlLine) (string, string, error) {
+	text := line.text
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text[1:], text[0])
+		if end < 0 {
+			return "", "", p.errorf(line.n, "unterminated quoted key")
+		}
+		key, _, err := p.scalar(text[:end+2], line.n)
+		if err != nil {
+			return "", "", err
+		}
+		rest := text[end+2:]
+		if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", "", p.errorf(line.n, "expected ':' after key %s", text[:end+2])
+		}
+		return key, strings.TrimSpace(rest[1:]), nil
+	}
+
+	idx := mappingColon(text)
+	if idx < 0 {
+		return "", "", p.errorf(line.n, "expected 'key: value', got %q", text)
+	}
+	key := strings.TrimSpace(text[:idx])
+	if key == "" {
+		return "", "", p.errorf(line.n, "empty mapping key")
+	}
+	if key[0] == '&' || key[0] == '*' || key[0] == '!' || key[0] == '?' {
+		return "", "", p.errorf(line.n, "unsupported key %q", key)
+	}
+	return key, strings.TrimSpace(text[idx+1:]), nil
+}
+
+// hasMappingKey indica se o texto tem a forma "chave: valor".
+func hasMappingKey(text string) bool {
+	return text[0] != '"' && text[0] != '\'' && mappingColon(text) > 0
+}
+
+// mappingColon retorna o índice do ':' que separa chave e valor (seguido de espaço
+// ou no fim da linha, antes de um comentário), ou -1.
+func mappingColon(text string) int {
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '#' && i > 0 && text[i-1] == ' ':
+			return -1
+		case text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ' || text[i+1] == '\t'):
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment remove um comentário (# precedido de espaço, fora de aspas) e os
+// espaços ao redor do valor.
+func stripYAMLComment(s string) string {
+	s = strings.TrimSpace(s)
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.ContainsRune(" [,", rune(s[i-1]))):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return s
+}
+
+// splitFlowItems separa os itens de uma lista [a, "b, c"] pelas vírgulas fora de aspas.
+func splitFlowItems(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, strings.TrimSpace(s[start:]))
+}
+
+// closingQuote retorna o índice da aspa que fecha um escalar YAML com aspas, sem a
+// aspa de abertura, ou -1. Com aspas duplas, \" não fecha o escalar; com aspas
+// simples, duas aspas seguidas representam uma aspa e também não o fecham.
+func closingQuote(body string, quote byte) int {
+	for i := 0; i < len(body); i++ {
+		switch {
+		case quote == '"' && body[i] == '\\':
+			i++
+		case quote == '\'' && strings.HasPrefix(body[i:], "''"):
+			i++
+		case body[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// isSequenceItem indica se a linha é um item de lista ("- valor" ou "-").
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// isDocumentMarker indica se a linha é o marcador informado (--- ou ...), opcionalmente
+// seguido de comentário.
+func isDocumentMarker(text, marker string) bool {
+	return text == marker || strings.HasPrefix(text, marker+" ")
+}
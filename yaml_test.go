@@ -0,0 +1,170 @@
+package configloader
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseYAML testa o subconjunto de YAML suportado
+func TestParseYAML(t *testing.T) {
+	content := `%YAML 1.2
+---
+# configuração
+app_name: api   # comentário
+port: 8080
+debug: yes
+database:
+  host: "db.internal"
+  max-conns: '10'
+  options:
+    ssl: true
+hosts:
+  - a.example.com
+  - "b.example.com"   # segundo
+tags: [x, "y, z", ~]
+empty:
+nothing: null
+url: http://example.com/#frag
+certificate: |
+  line one
+  line two
+
+folded: >-
+  first
+  second
+"quoted key": value
+'it''s': x
+services:
+- api
+- worker
+...
+`
+	values, err := parseYAML("config.yaml", content)
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+
+	expected := map[string]configValue{
+		"app_name":             {value: "api", line: 4},
+		"port":                 {value: "8080", line: 5},
+		"debug":                {value: "yes", line: 6},
+		"database.host":        {value: "db.internal", line: 8},
+		"database.max-conns":   {value: "10", line: 9},
+		"database.options.ssl": {value: "true", line: 11},
+		"hosts":                listValue([]string{"a.example.com", "b.example.com"}, 13),
+		"tags":                 listValue([]string{"x", "y, z"}, 15),
+		"url":                  {value: "http://example.com/#frag", line: 18},
+		"certificate":          {value: "line one\nline two\n", line: 19},
+		"folded":               {value: "first second", line: 23},
+		"quoted key":           {value: "value", line: 26},
+		"it's":                 {value: "x", line: 27},
+		"services":             listValue([]string{"api", "worker"}, 29),
+	}
+	for key, want := range expected {
+		if got, ok := values[key]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Key %s: Expected %+v, got %+v (found: %v)", key, want, got, ok)
+		}
+	}
+	for _, key := range []string{"empty", "nothing"} {
+		if _, ok := values[key]; ok {
+			t.Errorf("Expected null key %s to be absent", key)
+		}
+	}
+	if len(values) != len(expected) {
+		t.Errorf("Expected %d values, got %d: %v", len(expected), len(values), values)
+	}
+}
+
+// TestParseYAML_Errors testa as mensagens de erro com número da linha
+func TestParseYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"duplicate key", "a: 1\nb: 2\na: 3\n", `config.yaml:3: duplicate key "a" (first defined at line 1)`},
+		{"bad indentation", "a:\n  b: 1\n    c: 2\n", "config.yaml:3: unexpected indentation"},
+		{"tab indentation", "a:\n\tb: 1\n", "config.yaml:2: tabs are not allowed in indentation"},
+		{"missing colon", "a: 1\njust text\n", `config.yaml:2: expected 'key: value', got "just text"`},
+		{"unterminated string", "a: \"open\n", "config.yaml:1: unterminated double-quoted string"},
+		{"flow mapping", "a: {b: 1}\n", "config.yaml:1: flow mappings are not supported"},
+		{"alias", "a: *ref\n", "config.yaml:1: anchors and aliases are not supported"},
+		{"list of maps", "a:\n  - name: x\n", "config.yaml:2: only lists of scalar values are supported"},
+		{"multiple documents", "a: 1\n---\nb: 2\n", "config.yaml:2: multiple documents are not supported"},
+		{"top-level list", "- a\n", "config.yaml:1: top-level value must be a mapping"},
+		{"empty key", "a: 1\n: x\n", "config.yaml:2: empty mapping key"},
+		{"empty nested key", "a:\n  : x\n", "config.yaml:2: empty mapping key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAML("config.yaml", tt.content)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Expected error '%s', got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestParseYAML_DoubleQuotedEscapes testa os escapes de YAML em strings com aspas duplas,
+// incluindo os que não existem em Go
+func TestParseYAML_DoubleQuotedEscapes(t *testing.T) {
+	content := `path: "a\/b"
+nbsp: "x\_y"
+separators: "\N\L\P"
+space: "a\ b"
+hex: "\x41\u00e7\U0001F600"
+common: "tab\there\n\"q\" \\ \e"
+`
+	values, err := parseYAML("escapes.yaml", content)
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"path":       "a/b",
+		"nbsp":       "x\u00a0y",
+		"separators": "\u0085\u2028\u2029",
+		"space":      "a b",
+		"hex":        "Aç😀",
+		"common":     "tab\there\n\"q\" \\ \x1b",
+	}
+	for key, want := range expected {
+		if got := values[key].value; got != want {
+			t.Errorf("Key %s: Expected %q, got %q", key, want, got)
+		}
+	}
+
+	for _, bad := range []string{`a: "\q"`, `a: "\x4"`, `a: "\uD800"`, `a: "x"y"`} {
+		if _, err := parseYAML("escapes.yaml", bad+"\n"); err == nil {
+			t.Errorf("Expected error for %s, got nil", bad)
+		}
+	}
+}
+
+// TestParseYAML_Empty testa arquivos vazios ou só com comentários
+func TestParseYAML_Empty(t *testing.T) {
+	for _, content := range []string{"", "# nada\n", "---\n"} {
+		values, err := parseYAML("empty.yaml", content)
+		if err != nil || len(values) != 0 {
+			t.Errorf("Expected no values for %q, got %v (%v)", content, values, err)
+		}
+	}
+}
+
+// TestParseYAML_BlockChomping testa os indicadores de chomping dos blocos
+func TestParseYAML_BlockChomping(t *testing.T) {
+	values, err := parseYAML("block.yaml", "keep: |+\n  a\n\nstrip: |-\n  a\n  b\nnext: x\n")
+	if err != nil {
+		t.Fatalf("parseYAML failed: %v", err)
+	}
+	if got := values["keep"].value; got != "a\n\n" {
+		t.Errorf("Expected keep 'a\\n\\n', got %q", got)
+	}
+	if got := values["strip"].value; got != "a\nb" {
+		t.Errorf("Expected strip 'a\\nb', got %q", got)
+	}
+	if got := values["next"]; got.value != "x" || got.line != 7 {
+		t.Errorf("Expected next 'x' at line 7, got %+v", got)
+	}
+}